@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/reader"
+	offFormatters "github.com/raspi/heksa/pkg/reader/offsetFormatters/base"
+)
+
+// runCompare prints the same offsets of many files under each other and highlights columns where files disagree
+func runCompare(p params) {
+	// Offset is printed once per block, so files are printed without offsets
+	noOffsets := p
+	noOffsets.offsetViewer = nil
+	noOffsets.printRelative = false
+
+	var sides []*diffSide
+	var largest int64
+	var starts []uint64 // Offset of the first byte of each file
+	sameStart := true   // Do all files start at the same offset? Not with seeking from the end of files of different sizes.
+	for _, in := range p.inputs {
+		starts = append(starts, getInputOffset(in))
+		if starts[len(starts)-1] != starts[0] {
+			sameStart = false
+		}
+
+		sides = append(sides, &diffSide{r: newReader(noOffsets, in), in: in})
+
+		if in.filesize > largest {
+			largest = in.filesize
+		}
+	}
+
+	// Shared offset column
+	var offsetFormatter offFormatters.OffsetFormatter
+	offsetWidth := 0
+	if len(p.offsetViewer) > 0 {
+		offsetFormatter = reader.GetFromOffsetFormatter(p.offsetViewer[0], offFormatters.BaseInfo{FileSize: largest})
+		offsetWidth = offsetFormatter.GetFormatWidth()
+	}
+
+	labelFormat := fmt.Sprintf(`#%%-%dd`, len(fmt.Sprintf(`%d`, len(sides))))
+	splitter := p.colorGroupings[`Splitter`] + p.fg.Splitter
+
+	// Legend
+	for i, s := range sides {
+		_, _ = fmt.Println(fmt.Sprintf(labelFormat, i+1) + ` ` + s.in.name)
+	}
+	_, _ = fmt.Println()
+
+	diffColor := p.colorGroupings[`Diff`]
+	isIdentical := false // Was last printed block identical in all files?
+	identicalCount := 0
+	var differing uint64
+
+	lines := make([]reader.Line, len(sides))
+
+	// Offsets are counted from the start offsets, so that they are right even if some files have ended
+	for lineNumber := uint64(0); ; lineNumber++ {
+		size := 0
+
+		for i, s := range sides {
+			lines[i] = s.read(p.limit)
+
+			if len(lines[i].Data) > size {
+				size = len(lines[i].Data)
+			}
+		}
+
+		if size == 0 {
+			// All at EOF
+			break
+		}
+
+		// Find columns where files disagree
+		overlay := make([]string, size)
+		same := true
+		for pos := 0; pos < size; pos++ {
+			for _, l := range lines {
+				if pos >= len(l.Data) || pos >= len(lines[0].Data) || l.Data[pos] != lines[0].Data[pos] {
+					overlay[pos] = diffColor
+					differing++
+					same = false
+					break
+				}
+			}
+		}
+
+		if same && isIdentical {
+			// Collapse identical stretch
+			identicalCount++
+			continue
+		}
+
+		if identicalCount > 0 {
+			_, _ = fmt.Println(identicalLinesMessage(identicalCount, p.fg.Width))
+			identicalCount = 0
+		}
+
+		isIdentical = same
+
+		// <offset on the first row only, or on every row if files start at different offsets><label><line>
+		for i, s := range sides {
+			prefix := ``
+
+			if offsetFormatter != nil {
+				if i == 0 || !sameStart {
+					offset := starts[i] + lineNumber*uint64(p.fg.Width)
+					prefix = p.colorGroupings[`Offset`] + offsetFormatter.Print(offset) + splitter
+				} else {
+					prefix = strings.Repeat(` `, offsetWidth) + splitter
+				}
+			}
+
+			_, _ = fmt.Println(prefix + p.colorGroupings[`Offset`] + fmt.Sprintf(labelFormat, i+1) + splitter + s.r.FormatLine(lines[i], overlay))
+		}
+	}
+
+	if identicalCount > 0 {
+		_, _ = fmt.Println(identicalLinesMessage(identicalCount, p.fg.Width))
+	}
+
+	closeInputs(p.inputs)
+
+	_, _ = fmt.Println()
+	for i, s := range sides {
+		_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes from `+labelFormat+` %v total`, s.r.GetReadBytes(), i+1, s.in.name))
+	}
+	_, _ = fmt.Println(fmt.Sprintf(`%d differing columns`, differing))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/compat"
+)
+
+// runCompat dumps input in the exact layout of another tool
+func runCompat(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+
+	clear := ``
+	if p.compatColor {
+		clear = color.Clear
+	}
+
+	printer := compat.New(p.compatProfile, p.fg.Width, p.palette, clear)
+
+	var lastData []byte
+	isSqueezing := false
+	var end uint64
+	var total uint64
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		if p.limit > 0 && total+uint64(len(l.Data)) > p.limit {
+			// Print exactly the limited range like the other tools do
+			l.Data = l.Data[0 : p.limit-total]
+		}
+
+		total += uint64(len(l.Data))
+
+		if printer.Squeezes() && lastData != nil && bytes.Equal(l.Data, lastData) {
+			if !isSqueezing {
+				_, _ = fmt.Println(printer.Squeezed())
+				isSqueezing = true
+			}
+		} else {
+			isSqueezing = false
+			_, _ = fmt.Println(printer.Line(l.Offset, l.Data))
+		}
+
+		lastData = l.Data
+		end = l.Offset + uint64(len(l.Data))
+
+		if p.limit > 0 && total >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "couldn't close file: %v\n", err)
+		os.Exit(1)
+	}
+
+	if s, ok := printer.End(end, total); ok {
+		_, _ = fmt.Println(s)
+	}
+}
@@ -27,6 +27,20 @@ Splitter=194
 Offset=250
 Special=240
 Highlight=255
+; Differing bytes when comparing files
+Diff=196
+; Search matches
+Match=226
+; Bytes changed in interactive edit mode
+Changed=208
+; Special floating point values
+FloatNaN=201
+FloatInf=196
+FloatDenormal=244
+; Metadata columns (--meta) by value
+MetaLow=34
+MetaMedium=214
+MetaHigh=196
 ; EOF padding color
 Padding=237
 Default=255
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/diff"
+	"github.com/raspi/heksa/pkg/reader"
+)
+
+// diffSide is one of the compared files
+type diffSide struct {
+	r    *reader.Reader
+	in   input
+	done bool        // EOF or limit reached
+	next reader.Line // Empty line at the position where next line would start, used for padding after EOF
+}
+
+// read reads next line or returns empty line if side is already done
+func (s *diffSide) read(limit uint64) reader.Line {
+	if s.done {
+		return s.next
+	}
+
+	l, err := s.r.ReadLine()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.done = true
+			return s.next
+		}
+
+		_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file %v: %v`, s.in.name, err))
+		os.Exit(1)
+	}
+
+	if limit > 0 && s.r.GetReadBytes() >= limit {
+		// Limit is set and found
+		s.done = true
+	}
+
+	s.next = reader.Line{
+		Offset:         l.Offset + uint64(len(l.Data)),
+		RelativeOffset: l.RelativeOffset + uint64(len(l.Data)),
+	}
+
+	return l
+}
+
+// identicalLinesMessage is displayed instead of identical lines which were collapsed
+func identicalLinesMessage(count int, width int) string {
+	return "\t" + fmt.Sprintf(`-- %[1]d identical lines (%[2]d bytes (0x%04[2]x))`, count, count*width)
+}
+
+// runDiff compares two files byte by byte at the same offsets and prints them side by side
+func runDiff(p params) {
+	if p.diffAlign {
+		runAlignedDiff(p)
+		return
+	}
+
+	left := &diffSide{r: newReader(p, p.inputs[0]), in: p.inputs[0]}
+	right := &diffSide{r: newReader(p, p.inputs[1]), in: p.inputs[1]}
+
+	diffColor := p.colorGroupings[`Diff`]
+	cmp := diff.Positional{}
+
+	isIdentical := false // Was last printed line identical in both files?
+	identicalCount := 0
+
+	for !left.done || !right.done {
+		ll := left.read(p.limit)
+		rl := right.read(p.limit)
+
+		if len(ll.Data) == 0 && len(rl.Data) == 0 {
+			// Both at EOF
+			break
+		}
+
+		mask := cmp.Compare(ll.Data, rl.Data)
+
+		overlay := make([]string, len(mask))
+		same := true
+		for i, differs := range mask {
+			if differs {
+				overlay[i] = diffColor
+				same = false
+			}
+		}
+
+		if same && isIdentical {
+			// Collapse identical stretch
+			identicalCount++
+			continue
+		}
+
+		if identicalCount > 0 {
+			_, _ = fmt.Println(identicalLinesMessage(identicalCount, p.fg.Width))
+			identicalCount = 0
+		}
+
+		isIdentical = same
+
+		// <left file line> <right file line>
+		_, _ = fmt.Println(left.r.FormatLine(ll, overlay) + ` ` + right.r.FormatLine(rl, overlay))
+	}
+
+	if identicalCount > 0 {
+		_, _ = fmt.Println(identicalLinesMessage(identicalCount, p.fg.Width))
+	}
+
+	closeInputs(p.inputs)
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes from %v and %d bytes from %v total`, left.r.GetReadBytes(), left.in.name, right.r.GetReadBytes(), right.in.name))
+	_, _ = fmt.Println(fmt.Sprintf(`%d differing bytes in %d ranges`, cmp.DifferentBytes, cmp.Ranges))
+}
+
+// closeInputs closes all input files
+func closeInputs(inputs []input) {
+	for _, in := range inputs {
+		err := in.source.Close()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/diff"
+	"github.com/raspi/heksa/pkg/reader"
+)
+
+// readAll reads whole input (or until limit) into memory and returns also the offset where reading started
+func readAll(in input, limit uint64) (data []byte, offset uint64) {
+	var src io.Reader = in.source
+	offset = getInputOffset(in)
+
+	if limit > 0 {
+		src = io.LimitReader(src, int64(limit))
+	}
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error while reading file %v: %v`, in.name, err)
+		os.Exit(1)
+	}
+
+	return data, offset
+}
+
+// runAlignedDiff compares two files so that inserted and deleted bytes are detected and prints aligned hunks side by side
+func runAlignedDiff(p params) {
+	a, aOffset := readAll(p.inputs[0], p.limit)
+	b, bOffset := readAll(p.inputs[1], p.limit)
+	closeInputs(p.inputs)
+
+	hunks := diff.Align(a, b)
+
+	if p.diffHunks {
+		_, _ = fmt.Println("type\tleft_offset\tleft_length\tright_offset\tright_length")
+
+		for _, h := range hunks {
+			if h.Type == diff.HunkEqual {
+				continue
+			}
+
+			_, _ = fmt.Printf("%v\t%d\t%d\t%d\t%d\n", h.Type, aOffset+h.LeftOffset, h.LeftLength, bOffset+h.RightOffset, h.RightLength)
+		}
+
+		return
+	}
+
+	left := newReader(p, p.inputs[0])
+	right := newReader(p, p.inputs[1])
+
+	diffColor := p.colorGroupings[`Diff`]
+	width := uint64(p.fg.Width)
+
+	overlay := make([]string, width)
+	for i := range overlay {
+		overlay[i] = diffColor
+	}
+
+	// printRow prints one row of hunk side by side
+	printRow := func(h diff.Hunk, row uint64, overlay []string) {
+		ll := hunkLine(a, h.LeftOffset, h.LeftLength, row*width, width, aOffset)
+		rl := hunkLine(b, h.RightOffset, h.RightLength, row*width, width, bOffset)
+		_, _ = fmt.Println(left.FormatLine(ll, overlay) + ` ` + right.FormatLine(rl, overlay))
+	}
+
+	var differing uint64
+	changes := 0
+
+	for _, h := range hunks {
+		size := h.LeftLength
+		if h.RightLength > size {
+			size = h.RightLength
+		}
+
+		rows := (size + width - 1) / width
+
+		if h.Type == diff.HunkEqual {
+			if rows <= 2 {
+				for row := uint64(0); row < rows; row++ {
+					printRow(h, row, nil)
+				}
+
+				continue
+			}
+
+			// Collapse identical stretch, but show the first and the last line for context
+			printRow(h, 0, nil)
+			_, _ = fmt.Println(identicalLinesMessage(int(rows-2), p.fg.Width))
+			printRow(h, rows-1, nil)
+			continue
+		}
+
+		changes++
+		differing += size
+
+		for row := uint64(0); row < rows; row++ {
+			printRow(h, row, overlay)
+		}
+	}
+
+	_, _ = fmt.Println()
+
+	for _, h := range hunks {
+		if h.Type == diff.HunkEqual {
+			continue
+		}
+
+		_, _ = fmt.Println(fmt.Sprintf(`0x%08x -> 0x%08x: %v`, aOffset+h.LeftOffset, bOffset+h.RightOffset, h.Summary()))
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes from %v and %d bytes from %v total`, len(a), p.inputs[0].name, len(b), p.inputs[1].name))
+	_, _ = fmt.Println(fmt.Sprintf(`%d differing bytes in %d hunks`, differing, changes))
+}
+
+// hunkLine returns one line from given hunk of data. If the hunk is shorter than the requested position, an empty line is returned.
+func hunkLine(data []byte, hunkOffset uint64, hunkLength uint64, pos uint64, width uint64, baseOffset uint64) reader.Line {
+	if pos > hunkLength {
+		pos = hunkLength
+	}
+
+	end := pos + width
+	if end > hunkLength {
+		end = hunkLength
+	}
+
+	return reader.Line{
+		Offset:         baseOffset + hunkOffset + pos,
+		RelativeOffset: hunkOffset + pos,
+		Data:           data[hunkOffset+pos : hunkOffset+end],
+	}
+}
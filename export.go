@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/raspi/heksa/pkg/export"
+)
+
+// runExport prints input as array literal of a programming language
+func runExport(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+
+	name := p.exportName
+	if name == `` {
+		name = `data`
+
+		if in.filesize >= 0 {
+			name = export.DefaultName(p.exportLanguage, filepath.Base(in.name))
+		}
+	}
+
+	w, closeOutput := openOutput(p)
+
+	exporter := export.New(p.exportLanguage, name, int(p.fg.Width), p.exportComment)
+
+	_, _ = fmt.Fprintln(w, exporter.Header())
+
+	var total uint64
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		if p.limit > 0 && total+uint64(len(l.Data)) > p.limit {
+			// Export exactly the limited range
+			l.Data = l.Data[0 : p.limit-total]
+		}
+
+		_, _ = fmt.Fprintln(w, exporter.Line(l.Data))
+		total += uint64(len(l.Data))
+
+		if p.limit > 0 && total >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, exporter.Footer(total))
+
+	closeOutput()
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/extract"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/ascii"
+)
+
+// runStrings prints runs of printable characters like strings(1)
+func runStrings(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+	extractor := extract.New(p.encodings, getPrintableBytes(p.byteGroups), p.minLength)
+
+	splitter := p.colorGroupings[`Splitter`] + p.fg.Splitter
+	showEncoding := len(p.encodings) > 1
+	sb := strings.Builder{}
+	var count uint64
+
+	output := func(found []extract.Found) {
+		for _, f := range found {
+			count++
+
+			sb.Reset()
+			sb.WriteString(p.colorGroupings[`Offset`])
+			sb.WriteString(offsetFormatter.Print(f.Offset))
+			sb.WriteString(splitter)
+
+			if showEncoding {
+				sb.WriteString(p.colorGroupings[`Offset`])
+				sb.WriteString(fmt.Sprintf(`%-7s`, f.Encoding))
+				sb.WriteString(splitter)
+			}
+
+			for _, c := range f.Text {
+				if c < 0x80 {
+					// Color with byte palette
+					sb.WriteString(p.palette[c])
+					sb.WriteRune(ascii.AsciiByteToChar[c])
+				} else {
+					sb.WriteString(p.colorGroupings[`Default`])
+					sb.WriteRune(c)
+				}
+			}
+
+			sb.WriteString(color.Clear)
+			_, _ = fmt.Println(sb.String())
+		}
+	}
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		output(extractor.Feed(l.Offset, l.Data))
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	output(extractor.Flush())
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, r.GetReadBytes()))
+	_, _ = fmt.Println(fmt.Sprintf(`%d strings`, count))
+}
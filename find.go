@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/find"
+	"github.com/raspi/heksa/pkg/reader"
+)
+
+// contextPrinter prints only lines with matches and N lines of context around them (like grep -C)
+type contextPrinter struct {
+	r          *reader.Reader
+	context    int
+	before     []highlightedLine // Lines which may be printed as context before next match
+	after      int               // How many lines are still printed after the last match
+	dropped    bool              // Were lines dropped since the last printed line?
+	hasPrinted bool
+}
+
+// highlightedLine is a line with possible highlight overlay
+type highlightedLine struct {
+	line    reader.Line
+	overlay []string
+}
+
+func (c *contextPrinter) print(l highlightedLine, hasMatch bool) {
+	if hasMatch {
+		if c.dropped && c.hasPrinted {
+			// Separator between non-continuous groups
+			_, _ = fmt.Println(`--`)
+		}
+
+		for _, bl := range c.before {
+			_, _ = fmt.Println(c.r.FormatLine(bl.line, bl.overlay))
+		}
+
+		_, _ = fmt.Println(c.r.FormatLine(l.line, l.overlay))
+
+		c.before = nil
+		c.after = c.context
+		c.dropped = false
+		c.hasPrinted = true
+		return
+	}
+
+	if c.after > 0 {
+		_, _ = fmt.Println(c.r.FormatLine(l.line, l.overlay))
+		c.after--
+		return
+	}
+
+	c.before = append(c.before, l)
+	if len(c.before) > c.context {
+		c.before = c.before[1:]
+		c.dropped = true
+	}
+}
+
+// runFind dumps input and highlights matches of the search pattern
+func runFind(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+	scanner := find.NewScanner(p.findPattern)
+	size := uint64(scanner.Len())
+	matchColor := p.colorGroupings[`Match`]
+
+	collapser := newLineCollapser(os.Stdout, p.fg.Width)
+	ctx := &contextPrinter{r: r, context: p.context}
+
+	var pending []reader.Line // Lines which may still get highlights from matches that aren't complete yet
+	var matches []uint64      // Start offsets of matches which may still touch pending lines
+	var matchCount uint64
+
+	// output prints line after all matches touching it are known
+	output := func(l reader.Line) {
+		start := l.Offset
+		end := l.Offset + uint64(len(l.Data))
+
+		overlay := make([]string, len(l.Data))
+		hasMatch := false
+
+		keep := matches[:0]
+		for _, m := range matches {
+			for pos := m; pos < m+size; pos++ {
+				if pos >= start && pos < end {
+					overlay[pos-start] = matchColor
+					hasMatch = true
+				}
+			}
+
+			if m+size > end {
+				// Continues on the next line
+				keep = append(keep, m)
+			}
+		}
+		matches = keep
+
+		if p.context == -1 {
+			collapser.print(l.Data, r.FormatLine(l, overlay), !hasMatch)
+		} else {
+			ctx.print(highlightedLine{line: l, overlay: overlay}, hasMatch)
+		}
+	}
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		found := scanner.Feed(l.Offset, l.Data)
+		matchCount += uint64(len(found))
+		matches = append(matches, found...)
+		pending = append(pending, l)
+
+		fed := l.Offset + uint64(len(l.Data))
+
+		// Print lines which can't get more matches
+		for len(pending) > 0 && pending[0].Offset+uint64(len(pending[0].Data))+size-1 <= fed {
+			output(pending[0])
+			pending = pending[1:]
+		}
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	for _, l := range pending {
+		output(l)
+	}
+
+	collapser.flush()
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, r.GetReadBytes()))
+	_, _ = fmt.Println(fmt.Sprintf(`%d matches`, matchCount))
+}
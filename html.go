@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/output/htmlOutput"
+)
+
+// runHTML dumps input as HTML page
+func runHTML(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+	offsetFormatters := getOffsetFormatters(p, in)
+
+	groupColors, err := color.GetColorGroupIndexes(strings.NewReader(DefaultGroupColors))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error loading color group config: %v`, err)
+		os.Exit(1)
+	}
+
+	w, closeOutput := openOutput(p)
+
+	renderer := htmlOutput.New(groupColors, p.byteGroups, p.formatterNames, p.fg.Splitter)
+	collapser := newLineCollapser(w, p.fg.Width)
+
+	// Relative offset is not available for STDIN
+	printRelative := p.printRelative && in.filesize != -1
+
+	_, _ = fmt.Fprint(w, renderer.Header(`heksa - `+in.name))
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		offsetsLeft, offsetsRight := getLineOffsets(offsetFormatters, l, printRelative)
+
+		collapser.print(l.Data, renderer.Line(l.Offset, offsetsLeft, offsetsRight, l.Data, p.fg.Layout(l.Data)), true)
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	err = in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	collapser.flush()
+
+	_, _ = fmt.Fprintln(w, renderer.Footer(fmt.Sprintf(`Read %d bytes total`, r.GetReadBytes())))
+
+	closeOutput()
+}
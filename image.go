@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/term"
+	"github.com/raspi/heksa/pkg/visual"
+)
+
+// maxLinearImageBytes is the largest input rendered with linear layout, the image takes 4 bytes per input byte
+const maxLinearImageBytes = 256 * 1024 * 1024
+
+// imageChunkSize is the count of bytes read at a time, multiple of visual.EntropyBlockSize
+const imageChunkSize = 4096 * visual.EntropyBlockSize
+
+// runImage renders bytes of the input as pixels and saves the image as PNG and/or draws it to the terminal
+func runImage(p params) {
+	in := p.inputs[0]
+
+	groupColors, err := color.GetColorGroupIndexes(strings.NewReader(DefaultGroupColors))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error loading color group config: %v`, err)
+		os.Exit(1)
+	}
+
+	colorizer := visual.NewColorizer(p.imageMode, color.GetRGBPalette(p.byteGroups, groupColors))
+
+	var src io.Reader = in.source
+	if p.limit > 0 {
+		src = io.LimitReader(src, int64(p.limit))
+	}
+
+	// Linear and Hilbert layouts need the size of the input before drawing
+	size := int64(-1)
+	if in.filesize != -1 {
+		size = in.filesize - int64(getInputOffset(in))
+		if p.limit > 0 && int64(p.limit) < size {
+			size = int64(p.limit)
+		}
+	} else if p.imageLayout != visual.LayoutDigraph {
+		limit := p.limit
+		if p.imageLayout == visual.LayoutLinear && (limit == 0 || limit > maxLinearImageBytes) {
+			// Read one byte more to detect too large input
+			limit = maxLinearImageBytes + 1
+		}
+
+		data, _ := readAll(in, limit)
+		src = bytes.NewReader(data)
+		size = int64(len(data))
+	}
+
+	if p.imageLayout == visual.LayoutLinear && size > maxLinearImageBytes {
+		_, _ = fmt.Fprintf(os.Stderr, "input is too large for linear image (max %d bytes), use --limit or --image-layout hilbert\n", maxLinearImageBytes)
+		os.Exit(1)
+	}
+
+	var canvas visual.Canvas
+
+	switch p.imageLayout {
+	case visual.LayoutHilbert:
+		canvas = visual.NewHilbert(colorizer, int(size), p.imageWidth)
+	case visual.LayoutDigraph:
+		canvas = visual.NewDigraph()
+	default:
+		canvas = visual.NewLinear(colorizer, int(size), p.imageWidth)
+	}
+
+	var total int
+	buf := make([]byte, imageChunkSize)
+
+	for {
+		n, err := io.ReadFull(src, buf)
+		canvas.Draw(buf[:n])
+		total += n
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error while reading file %v: %v`, in.name, err)
+			os.Exit(1)
+		}
+	}
+
+	closeInputs(p.inputs)
+
+	img := canvas.Image()
+
+	if p.imageTerminal {
+		for _, line := range visual.Terminal(visual.Downscale(img, getTerminalWidth())) {
+			_, _ = fmt.Println(line)
+		}
+	}
+
+	if p.imageFile == `` {
+		return
+	}
+
+	f, err := os.Create(p.imageFile)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error opening image file: %v`, err)
+		os.Exit(1)
+	}
+
+	err = png.Encode(f, img)
+	if err != nil {
+		_ = f.Close()
+		_, _ = fmt.Fprintf(os.Stderr, `error writing image: %v`, err)
+		os.Exit(1)
+	}
+
+	err = f.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close image file: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = fmt.Printf("Wrote %d bytes as %dx%d image to %v\n", total, img.Bounds().Dx(), img.Bounds().Dy(), p.imageFile)
+}
+
+// getTerminalWidth returns terminal width in characters.
+// Size of the terminal is used if STDOUT is a terminal, then COLUMNS environment variable and 80 if neither is available.
+func getTerminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+
+	w, err := strconv.Atoi(os.Getenv(`COLUMNS`))
+	if err != nil || w < 1 {
+		return 80
+	}
+
+	return w
+}
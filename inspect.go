@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/decode"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/hex"
+)
+
+// Count of bytes read for inspection, enough for the longest interpretation (IPv6 and GUID)
+const inspectSize = 16
+
+// Shown when there isn't enough bytes for the interpretation
+const inspectMissing = `-`
+
+// inspectValue returns interpretation of data in given byte order. Data has at least as many bytes as the row needs.
+type inspectValue func(data []byte, order binary.ByteOrder) string
+
+// inspectRow is one line of the inspector table
+type inspectRow struct {
+	name    string
+	size    int  // Bytes needed
+	ordered bool // Print both little and big endian interpretation
+	value   inspectValue
+}
+
+// Interpretations in the order they are printed
+var inspectRows = []inspectRow{
+	{`int8`, 1, false, func(d []byte, o binary.ByteOrder) string { return strconv.FormatInt(int64(int8(d[0])), 10) }},
+	{`uint8`, 1, false, func(d []byte, o binary.ByteOrder) string { return strconv.FormatUint(uint64(d[0]), 10) }},
+	{`int16`, 2, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatInt(int64(int16(o.Uint16(d))), 10) }},
+	{`uint16`, 2, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatUint(uint64(o.Uint16(d)), 10) }},
+	{`int32`, 4, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatInt(int64(int32(o.Uint32(d))), 10) }},
+	{`uint32`, 4, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatUint(uint64(o.Uint32(d)), 10) }},
+	{`int64`, 8, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatInt(int64(o.Uint64(d)), 10) }},
+	{`uint64`, 8, true, func(d []byte, o binary.ByteOrder) string { return strconv.FormatUint(o.Uint64(d), 10) }},
+	{`float16`, 2, true, func(d []byte, o binary.ByteOrder) string { return inspectFloat(decode.Float16, uint64(o.Uint16(d))) }},
+	{`bfloat16`, 2, true, func(d []byte, o binary.ByteOrder) string { return inspectFloat(decode.BFloat16, uint64(o.Uint16(d))) }},
+	{`float32`, 4, true, func(d []byte, o binary.ByteOrder) string { return inspectFloat(decode.Float32, uint64(o.Uint32(d))) }},
+	{`float64`, 8, true, func(d []byte, o binary.ByteOrder) string { return inspectFloat(decode.Float64, o.Uint64(d)) }},
+	{`uleb128`, 1, false, func(d []byte, o binary.ByteOrder) string {
+		v, n := decode.ULEB128(d)
+		return inspectVarint(strconv.FormatUint(v, 10), n)
+	}},
+	{`sleb128`, 1, false, func(d []byte, o binary.ByteOrder) string {
+		v, n := decode.SLEB128(d)
+		return inspectVarint(strconv.FormatInt(v, 10), n)
+	}},
+	{`varint (zigzag)`, 1, false, func(d []byte, o binary.ByteOrder) string {
+		v, n := decode.ULEB128(d)
+		return inspectVarint(strconv.FormatInt(decode.ZigZag(v), 10), n)
+	}},
+	{`UTF-8`, 1, false, func(d []byte, o binary.ByteOrder) string {
+		r, size := utf8.DecodeRune(d)
+		if r == utf8.RuneError && size <= 1 {
+			return `invalid`
+		}
+
+		return inspectRune(r, size)
+	}},
+	{`UTF-16`, 2, true, func(d []byte, o binary.ByteOrder) string {
+		r, size := decode.UTF16Rune(d, o)
+		if r == utf8.RuneError {
+			return `invalid`
+		}
+
+		return inspectRune(r, size)
+	}},
+	{`Unix time32`, 4, true, func(d []byte, o binary.ByteOrder) string {
+		return inspectTime(time.Unix(int64(int32(o.Uint32(d))), 0))
+	}},
+	{`Unix time64`, 8, true, func(d []byte, o binary.ByteOrder) string {
+		return inspectTime(time.Unix(int64(o.Uint64(d)), 0))
+	}},
+	{`FILETIME`, 8, true, func(d []byte, o binary.ByteOrder) string { return inspectTime(decode.FileTime(o.Uint64(d))) }},
+	{`DOS date/time`, 4, true, func(d []byte, o binary.ByteOrder) string {
+		t, ok := decode.DOSDateTime(o.Uint32(d))
+		if !ok {
+			return `invalid`
+		}
+
+		return inspectTime(t)
+	}},
+	{`GUID / UUID`, decode.UUIDSize, true, func(d []byte, o binary.ByteOrder) string {
+		if o == binary.LittleEndian {
+			return decode.FormatGUID(d)
+		}
+
+		return decode.FormatUUID(d)
+	}},
+	{`IPv4`, net.IPv4len, false, func(d []byte, o binary.ByteOrder) string { return net.IP(d[0:net.IPv4len]).String() }},
+	{`IPv6`, net.IPv6len, false, func(d []byte, o binary.ByteOrder) string { return net.IP(d[0:net.IPv6len]).String() }},
+	{`MAC`, 6, false, func(d []byte, o binary.ByteOrder) string { return net.HardwareAddr(d[0:6]).String() }},
+}
+
+// runInspect prints every interpretation of the bytes at the seeked offset
+func runInspect(p params) {
+	in := p.inputs[0]
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+
+	offset := getInputOffset(in)
+
+	if in.filesize == -1 && p.seek > 0 {
+		// STDIN can't be seeked
+		n, err := io.CopyN(io.Discard, in.source, p.seek)
+		offset = uint64(n)
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			_, _ = fmt.Fprintf(os.Stderr, `error while reading file: %v`, err)
+			os.Exit(1)
+		}
+	}
+
+	data := make([]byte, inspectSize)
+	n, err := io.ReadFull(in.source, data)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		_, _ = fmt.Fprintf(os.Stderr, `error while reading file: %v`, err)
+		os.Exit(1)
+	}
+
+	data = data[0:n]
+	closeInputs(p.inputs)
+
+	if len(data) == 0 {
+		_, _ = fmt.Fprintf(os.Stderr, `error: no data at offset %v`, offset)
+		os.Exit(1)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	var sb strings.Builder
+	for i, b := range data {
+		if i > 0 {
+			sb.WriteString(` `)
+		}
+
+		sb.WriteString(p.palette[b] + hex.HexByteToString[b])
+	}
+
+	_, _ = fmt.Fprintf(w, "Offset: %s\n", offsetFormatter.Print(offset))
+	_, _ = fmt.Fprintf(w, "Bytes:  %s%s\n", sb.String(), color.Clear)
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%s%-16s %-40s %s%s\n", p.colorGroupings[`Highlight`], `Type`, `Little endian`, `Big endian`, color.Clear)
+
+	for _, row := range inspectRows {
+		le := inspectMissing
+		be := ``
+
+		if len(data) >= row.size {
+			le = row.value(data, binary.LittleEndian)
+		}
+
+		if row.ordered {
+			be = inspectMissing
+
+			if len(data) >= row.size {
+				be = row.value(data, binary.BigEndian)
+			}
+		}
+
+		_, _ = fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf(`%-16s %-40s %s`, row.name, le, be), ` `))
+	}
+}
+
+// inspectFloat returns float value, denormal values are marked
+func inspectFloat(format decode.FloatFormat, bits uint64) string {
+	v, class := format.Decode(bits)
+
+	bitSize := 32
+	if format == decode.Float64 {
+		bitSize = 64
+	}
+
+	s := strconv.FormatFloat(v, 'g', -1, bitSize)
+
+	if class == decode.FloatDenormal {
+		s += ` (denormal)`
+	}
+
+	return s
+}
+
+// inspectVarint returns varint value with its length, n is 0 for invalid value
+func inspectVarint(value string, n int) string {
+	if n == 0 {
+		return `invalid`
+	}
+
+	return fmt.Sprintf(`%s (%d B)`, value, n)
+}
+
+// inspectRune returns printable form of character with its code point and length
+func inspectRune(r rune, size int) string {
+	if !unicode.IsPrint(r) {
+		return fmt.Sprintf(`U+%04X (%d B)`, r, size)
+	}
+
+	return fmt.Sprintf(`'%c' U+%04X (%d B)`, r, r, size)
+}
+
+// inspectTime returns time in ISO 8601 (UTC) format
+func inspectTime(t time.Time) string {
+	t = t.UTC()
+
+	if t.Year() < 0 || t.Year() > 9999 {
+		return `out of range`
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
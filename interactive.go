@@ -0,0 +1,752 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/edit"
+	"github.com/raspi/heksa/pkg/find"
+	"github.com/raspi/heksa/pkg/reader"
+	offFormatters "github.com/raspi/heksa/pkg/reader/offsetFormatters/base"
+	"github.com/raspi/heksa/pkg/term"
+	"github.com/raspi/heksa/pkg/units"
+)
+
+// Help text of interactive mode
+var interactiveHelp = []string{
+	`Keys:`,
+	``,
+	`  q, Esc, Ctrl-C         Quit`,
+	`  Down, j, Enter         Scroll one line down`,
+	`  Up, k                  Scroll one line up`,
+	`  PgDn, Space, Ctrl-F    Scroll one page down`,
+	`  PgUp, b, Ctrl-B        Scroll one page up`,
+	`  Home, g                Go to start`,
+	`  End, G                 Go to end`,
+	`  Right, Left            Move one byte forward or backward (changes line alignment)`,
+	`  :                      Go to offset, see --seek for the syntax`,
+	`  /                      Search forward`,
+	`  ?                      Search backward`,
+	`  n, N                   Repeat search in the same or in the opposite direction`,
+	`  f                      Change formatters`,
+	`  w                      Change width`,
+	`  <, >                   Decrease or increase width by one`,
+	`  e                      Edit mode`,
+	`  u, Ctrl-Z              Undo`,
+	`  U, Ctrl-Y              Redo`,
+	`  Ctrl-S                 Save`,
+	`  h                      This help`,
+	``,
+	`Edit mode:`,
+	``,
+	`  Arrows, PgUp, PgDn     Move cursor`,
+	`  Home, End              Move cursor to the start or end of the line`,
+	`  Tab                    Move cursor to the next formatter (` + strings.Join(getEditableFormatterList(), `, `) + `)`,
+	`  0-9, a-f, ..           Overwrite byte in the base of the formatter, in 'asc' type the character`,
+	`  Enter                  Write the typed digits when there are less digits than in the formatter`,
+	`  Backspace              Remove typed digit`,
+	`  Ctrl-Z, Ctrl-Y         Undo and redo`,
+	`  Ctrl-S                 Save`,
+	`  Esc                    Cancel typed digits or leave edit mode`,
+	``,
+	`Search:`,
+	``,
+	`  x:<hex pattern>        Hex bytes with wildcards, same as --find (x:7f 45 4c 46 ?? 01)`,
+	`  <type>:<value>         Typed value, same as --search (u32le:1337)`,
+	`  anything else          Text`,
+	``,
+	`Press any key to continue`,
+}
+
+// pager is the state of interactive mode
+type pager struct {
+	p               params
+	in              input
+	r               *reader.Reader
+	offsetFormatter offFormatters.OffsetFormatter
+	out             *bufio.Writer
+	keys            chan term.Key
+	resize          chan os.Signal
+	start           uint64 // First offset which can be displayed (--seek)
+	end             uint64 // Offset after the last byte which can be displayed (--limit or file size)
+	top             uint64 // Offset of the first line on screen
+	columns         int    // Terminal width
+	rows            int    // Terminal height
+	message         string // Message displayed on status line
+	search          find.Pattern
+	searchBackward  bool
+	match           uint64 // Offset of highlighted search match
+	matchLength     int    // Length of highlighted search match, 0 = nothing highlighted
+	buffer          *edit.Buffer
+	editing         bool   // Edit mode is active
+	cursor          uint64 // Offset of the byte under cursor in edit mode
+	editFormatter   int    // Index of the formatter where cursor is
+	pending         string // Digits typed for the byte under cursor
+}
+
+// runInteractive displays the input in full screen and reads commands from keyboard
+func runInteractive(p params) {
+	in := p.inputs[0]
+
+	if in.filesize == -1 {
+		_, _ = fmt.Fprintln(os.Stderr, `error: --interactive needs a file, STDIN can't be seeked`)
+		os.Exit(1)
+	}
+
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		_, _ = fmt.Fprintln(os.Stderr, `error: --interactive needs a terminal`)
+		os.Exit(1)
+	}
+
+	original, ok := in.source.(io.ReaderAt)
+	if !ok {
+		_, _ = fmt.Fprintln(os.Stderr, `error: --interactive needs a regular file`)
+		os.Exit(1)
+	}
+
+	pg := &pager{
+		p:               p,
+		in:              in,
+		buffer:          edit.New(original, uint64(in.filesize)),
+		offsetFormatter: getOffsetFormatter(p, in.filesize),
+		out:             bufio.NewWriter(os.Stdout),
+		keys:            make(chan term.Key),
+		resize:          make(chan os.Signal, 1),
+		start:           getInputOffset(in),
+		end:             uint64(in.filesize),
+	}
+
+	pg.top = pg.start
+
+	// Changes are kept in the buffer until saved
+	pg.in.source = pg.buffer
+
+	err := pg.resetReader()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error: %v`, err)
+		os.Exit(1)
+	}
+
+	if p.limit > 0 && pg.start+p.limit < pg.end {
+		pg.end = pg.start + p.limit
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = pg.out.WriteString(term.EnterAltScreen + term.HideCursor + term.DisableWrap)
+
+	err = pg.run()
+
+	_, _ = pg.out.WriteString(color.Clear + term.EnableWrap + term.ShowCursor + term.LeaveAltScreen)
+	_ = pg.out.Flush()
+
+	restoreErr := term.Restore(fd, state)
+
+	closeInputs([]input{pg.in})
+
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error: %v`, err)
+		os.Exit(1)
+	}
+
+	if restoreErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error: %v`, restoreErr)
+		os.Exit(1)
+	}
+}
+
+// run reads keys and redraws the screen until user quits
+func (pg *pager) run() error {
+	go func() {
+		keyReader := bufio.NewReader(os.Stdin)
+
+		for {
+			k, err := term.ReadKey(keyReader)
+			if err != nil {
+				close(pg.keys)
+				return
+			}
+
+			pg.keys <- k
+		}
+	}()
+
+	term.NotifyResize(pg.resize)
+
+	for {
+		err := pg.updateSize()
+		if err != nil {
+			return err
+		}
+
+		err = pg.draw()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-pg.resize:
+			continue
+		case k, ok := <-pg.keys:
+			if !ok {
+				return nil
+			}
+
+			pg.message = ``
+
+			quit, err := pg.handleKey(k)
+			if err != nil {
+				return err
+			}
+
+			if quit {
+				return nil
+			}
+		}
+	}
+}
+
+// handleKey runs the command of given key
+func (pg *pager) handleKey(k term.Key) (quit bool, err error) {
+	if pg.editing {
+		return pg.handleEditKey(k)
+	}
+
+	width := uint64(pg.p.fg.Width)
+	page := uint64(pg.lines()) * width
+
+	switch k.Type {
+	case term.KeyEscape:
+		return pg.confirmQuit(), nil
+	case term.KeyDown, term.KeyEnter:
+		pg.scrollTo(pg.top + width)
+	case term.KeyUp:
+		pg.scrollBack(width)
+	case term.KeyPageDown:
+		pg.scrollTo(pg.top + page)
+	case term.KeyPageUp:
+		pg.scrollBack(page)
+	case term.KeyHome:
+		pg.top = pg.start
+	case term.KeyEnd:
+		pg.scrollTo(pg.end)
+	case term.KeyRight:
+		pg.scrollTo(pg.top + 1)
+	case term.KeyLeft:
+		pg.scrollBack(1)
+	case term.KeyCtrl:
+		switch k.Rune {
+		case 'c':
+			return pg.confirmQuit(), nil
+		case 'f':
+			pg.scrollTo(pg.top + page)
+		case 'b':
+			pg.scrollBack(page)
+		case 'z':
+			pg.undo()
+		case 'y':
+			pg.redo()
+		case 's':
+			pg.save()
+		}
+	case term.KeyRune:
+		switch k.Rune {
+		case 'q':
+			return pg.confirmQuit(), nil
+		case 'j':
+			pg.scrollTo(pg.top + width)
+		case 'k':
+			pg.scrollBack(width)
+		case ' ':
+			pg.scrollTo(pg.top + page)
+		case 'b':
+			pg.scrollBack(page)
+		case 'g':
+			pg.top = pg.start
+		case 'G':
+			pg.scrollTo(pg.end)
+		case ':':
+			pg.gotoPrompt()
+		case '/', '?':
+			pg.searchPrompt(k.Rune == '?')
+		case 'n':
+			return false, pg.repeatSearch(pg.searchBackward)
+		case 'N':
+			return false, pg.repeatSearch(!pg.searchBackward)
+		case 'f':
+			pg.formatPrompt()
+		case 'w':
+			pg.widthPrompt()
+		case '<':
+			pg.setWidth(width - 1)
+		case '>':
+			pg.setWidth(width + 1)
+		case 'e':
+			pg.startEditing()
+		case 'u':
+			pg.undo()
+		case 'U':
+			pg.redo()
+		case 'h':
+			return false, pg.showHelp()
+		}
+	}
+
+	return false, nil
+}
+
+// updateSize reads terminal size
+func (pg *pager) updateSize() (err error) {
+	pg.columns, pg.rows, err = term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return err
+	}
+
+	if pg.rows < 2 {
+		// Make room for at least one line and the status line
+		pg.rows = 2
+	}
+
+	return nil
+}
+
+// lines returns count of lines displayed (status line excluded)
+func (pg *pager) lines() int {
+	return pg.rows - 1
+}
+
+// lastTop returns top offset of the last page so that the last byte is on the last line.
+// Line alignment of the current top offset is kept.
+func (pg *pager) lastTop() uint64 {
+	width := uint64(pg.p.fg.Width)
+	page := uint64(pg.lines()) * width
+
+	if pg.end-pg.start <= page {
+		return pg.start
+	}
+
+	return pg.alignUp(pg.end - page)
+}
+
+// alignDown returns offset of the line which contains given offset when lines are aligned as the current top offset
+func (pg *pager) alignDown(offset uint64) uint64 {
+	width := uint64(pg.p.fg.Width)
+	aligned := offset - (offset+width-pg.top%width)%width
+
+	if aligned < pg.start || aligned > offset {
+		return pg.start
+	}
+
+	return aligned
+}
+
+// alignUp returns the first offset which is at or after given offset and aligned as the current top offset
+func (pg *pager) alignUp(offset uint64) uint64 {
+	aligned := pg.alignDown(offset)
+	if aligned < offset {
+		aligned += uint64(pg.p.fg.Width)
+	}
+
+	return aligned
+}
+
+// scrollTo sets top offset but doesn't scroll past the last page
+func (pg *pager) scrollTo(offset uint64) {
+	last := pg.lastTop()
+	if offset > last {
+		offset = last
+	}
+
+	pg.top = offset
+}
+
+// scrollBack moves top offset backward but not before the start
+func (pg *pager) scrollBack(amount uint64) {
+	if pg.top < pg.start+amount {
+		pg.top = pg.start
+		return
+	}
+
+	pg.top -= amount
+}
+
+// show scrolls so that given offset is visible
+func (pg *pager) show(offset uint64) {
+	page := uint64(pg.lines()) * uint64(pg.p.fg.Width)
+
+	if offset >= pg.top && offset < pg.top+page {
+		return
+	}
+
+	pg.scrollTo(pg.alignDown(offset))
+}
+
+// overlay returns highlight colors of changed bytes and the search match for given line
+func (pg *pager) overlay(l reader.Line) []string {
+	if pg.matchLength == 0 && !pg.buffer.IsModified() {
+		return nil
+	}
+
+	matchEnd := pg.match + uint64(pg.matchLength)
+
+	overlay := make([]string, len(l.Data))
+	for i := range overlay {
+		offset := l.Offset + uint64(i)
+
+		if pg.matchLength > 0 && offset >= pg.match && offset < matchEnd {
+			overlay[i] = pg.p.colorGroupings[`Match`]
+		} else if pg.buffer.IsChanged(offset) {
+			overlay[i] = pg.p.colorGroupings[`Changed`]
+		}
+	}
+
+	return overlay
+}
+
+// resetReader creates a new reader for the current formatters. Source is rewound to the start so that relative offsets begin from zero.
+func (pg *pager) resetReader() error {
+	_, err := pg.in.source.Seek(int64(pg.start), io.SeekStart)
+	if err != nil {
+		return fmt.Errorf(`couldn't seek: %w`, err)
+	}
+
+	pg.r = newReader(pg.p, pg.in)
+
+	return nil
+}
+
+// draw draws visible lines and the status line
+func (pg *pager) draw() error {
+	err := pg.r.Seek(pg.top)
+	if err != nil {
+		return err
+	}
+
+	_, _ = pg.out.WriteString(term.Home)
+
+	for row := 0; row < pg.lines(); row++ {
+		l, err := pg.r.ReadLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		if l.Offset+uint64(len(l.Data)) > pg.end {
+			// Limit
+			if l.Offset >= pg.end {
+				l.Data = nil
+			} else {
+				l.Data = l.Data[0 : pg.end-l.Offset]
+			}
+		}
+
+		if len(l.Data) > 0 {
+			_, _ = pg.out.WriteString(pg.r.FormatLineWithCursor(l, pg.overlay(l), pg.lineCursor(l)))
+		}
+
+		_, _ = pg.out.WriteString(term.ClearLine + "\r\n")
+	}
+
+	pg.drawStatus(pg.status())
+
+	return pg.out.Flush()
+}
+
+// status returns text of the status line
+func (pg *pager) status() string {
+	percent := 100.0
+	if pg.end > pg.start {
+		percent = 100 * float64(pg.top-pg.start) / float64(pg.end-pg.start)
+	}
+
+	s := fmt.Sprintf(`%v  %v (%.1f%%)  %v  width %d`,
+		pg.in.name, strings.TrimSpace(pg.offsetFormatter.Print(pg.top)), percent, strings.Join(pg.p.formatterNames, `,`), pg.p.fg.Width,
+	)
+
+	if pg.buffer.IsModified() {
+		s += `  [modified]`
+	}
+
+	if pg.editing {
+		s += fmt.Sprintf(`  EDIT %v %v`, pg.p.formatterNames[pg.editFormatter], strings.TrimSpace(pg.offsetFormatter.Print(pg.cursor)))
+
+		if pg.pending != `` {
+			s += `: ` + pg.pending + `_`
+		}
+	}
+
+	if pg.message != `` {
+		return s + `  ` + pg.message
+	}
+
+	return s + `  h = help`
+}
+
+// drawStatus draws text on the last line with reverse colors
+func (pg *pager) drawStatus(s string) {
+	if utf8.RuneCountInString(s) > pg.columns {
+		s = string([]rune(s)[0:pg.columns])
+	}
+
+	s += strings.Repeat(` `, pg.columns-utf8.RuneCountInString(s))
+
+	_, _ = pg.out.WriteString(term.MoveTo(pg.rows, 1) + color.Clear + color.SetReverseOn + s + color.Clear)
+}
+
+// prompt asks for a value on the status line. Escape cancels.
+func (pg *pager) prompt(label string, value string) (string, bool) {
+	_, _ = pg.out.WriteString(term.ShowCursor)
+	defer func() {
+		_, _ = pg.out.WriteString(term.HideCursor)
+	}()
+
+	for {
+		s := label + value
+		pg.drawStatus(s)
+		_, _ = pg.out.WriteString(term.MoveTo(pg.rows, utf8.RuneCountInString(s)+1))
+		_ = pg.out.Flush()
+
+		k, ok := <-pg.keys
+		if !ok {
+			return ``, false
+		}
+
+		switch k.Type {
+		case term.KeyEnter:
+			return value, true
+		case term.KeyEscape:
+			return ``, false
+		case term.KeyCtrl:
+			if k.Rune == 'c' {
+				return ``, false
+			}
+		case term.KeyBackspace:
+			if value != `` {
+				_, size := utf8.DecodeLastRuneInString(value)
+				value = value[0 : len(value)-size]
+			}
+		case term.KeyRune:
+			value += string(k.Rune)
+		}
+	}
+}
+
+// gotoPrompt asks for offset and scrolls there. Negative offset is from the end.
+func (pg *pager) gotoPrompt() {
+	s, ok := pg.prompt(`Go to offset: `, ``)
+	if !ok || s == `` {
+		return
+	}
+
+	offset, err := units.Parse(strings.Replace(strings.TrimSpace(s), `\`, ``, -1))
+	if err != nil {
+		pg.message = fmt.Sprintf(`invalid offset: %v`, err)
+		return
+	}
+
+	if offset < 0 {
+		offset += int64(pg.end)
+	}
+
+	if offset < int64(pg.start) || uint64(offset) >= pg.end {
+		pg.message = fmt.Sprintf(`offset %d is out of range`, offset)
+		return
+	}
+
+	pg.top = uint64(offset)
+}
+
+// parseSearchQuery parses search of interactive mode: "x:<hex pattern>", "<type>:<value>" or plain text
+func parseSearchQuery(s string) (find.Pattern, error) {
+	if strings.HasPrefix(s, `x:`) {
+		return find.ParseHex(s[2:])
+	}
+
+	typ := strings.SplitN(s, `:`, 2)[0]
+	for _, t := range find.GetTypedValueList() {
+		if typ == t || typ+`le` == t {
+			return find.ParseTypedValue(s)
+		}
+	}
+
+	return find.NewBytePattern([]byte(s)), nil
+}
+
+// searchPrompt asks for search query and searches it
+func (pg *pager) searchPrompt(backward bool) {
+	label := `Search forward: `
+	if backward {
+		label = `Search backward: `
+	}
+
+	s, ok := pg.prompt(label, ``)
+	if !ok || s == `` {
+		return
+	}
+
+	pattern, err := parseSearchQuery(s)
+	if err != nil {
+		pg.message = fmt.Sprintf(`invalid search: %v`, err)
+		return
+	}
+
+	pg.search = pattern
+	pg.searchBackward = backward
+	pg.matchLength = 0
+
+	err = pg.repeatSearch(backward)
+	if err != nil {
+		pg.message = fmt.Sprintf(`search failed: %v`, err)
+	}
+}
+
+// repeatSearch searches the next match of the previous search starting from the current match or top of the screen
+func (pg *pager) repeatSearch(backward bool) error {
+	if pg.search == nil {
+		pg.message = `no previous search`
+		return nil
+	}
+
+	from := pg.top
+	if pg.matchLength > 0 {
+		from = pg.match
+		if !backward {
+			from++
+		}
+	}
+
+	var offset uint64
+	var found bool
+	var err error
+
+	if backward {
+		offset, found, err = find.SearchBackward(pg.in.source, pg.search, from)
+	} else {
+		offset, found, err = find.SearchForward(pg.in.source, pg.search, from)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !found || offset < pg.start || offset+uint64(pg.search.Len()) > pg.end {
+		pg.message = `not found`
+		return nil
+	}
+
+	pg.match = offset
+	pg.matchLength = pg.search.Len()
+	pg.show(offset)
+	pg.message = `found at ` + strings.TrimSpace(pg.offsetFormatter.Print(offset))
+
+	return nil
+}
+
+// formatPrompt asks for new byte formatters
+func (pg *pager) formatPrompt() {
+	s, ok := pg.prompt(`Formatters (`+strings.Join(reader.GetViewerList(), `, `)+`): `, strings.Join(pg.p.formatterNames, `,`))
+	if !ok {
+		return
+	}
+
+	names := strings.Split(s, `,`)
+
+	displays, err := reader.GetViewers(names)
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	fg, err := getFormatterGroup(pg.p, displays, uint16(pg.p.fg.Width))
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	pg.p.formatterNames = names
+	pg.p.fg = fg
+	pg.editFormatter = 0
+	pg.pending = ``
+
+	if pg.editing && !pg.nextEditableFormatter() {
+		pg.editing = false
+	}
+
+	err = pg.resetReader()
+	if err != nil {
+		pg.message = err.Error()
+	}
+}
+
+// widthPrompt asks for new width
+func (pg *pager) widthPrompt() {
+	s, ok := pg.prompt(`Width: `, fmt.Sprintf(`%d`, pg.p.fg.Width))
+	if !ok {
+		return
+	}
+
+	width, err := units.Parse(strings.TrimSpace(s))
+	if err != nil {
+		pg.message = fmt.Sprintf(`invalid width: %v`, err)
+		return
+	}
+
+	pg.setWidth(uint64(width))
+}
+
+// setWidth changes count of bytes per line
+func (pg *pager) setWidth(width uint64) {
+	if width < 1 || width > 0xFFFF {
+		pg.message = `width must be 1 - 65535`
+		return
+	}
+
+	displays, err := reader.GetViewers(pg.p.formatterNames)
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	fg, err := getFormatterGroup(pg.p, displays, uint16(width))
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	pg.p.fg = fg
+	pg.scrollTo(pg.top)
+
+	err = pg.resetReader()
+	if err != nil {
+		pg.message = err.Error()
+	}
+}
+
+// showHelp displays help until a key is pressed
+func (pg *pager) showHelp() error {
+	_, _ = pg.out.WriteString(term.Home + color.Clear + term.ClearScreen)
+
+	for _, s := range interactiveHelp {
+		_, _ = pg.out.WriteString(s + term.ClearLine + "\r\n")
+	}
+
+	err := pg.out.Flush()
+	if err != nil {
+		return err
+	}
+
+	<-pg.keys
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/edit"
+	"github.com/raspi/heksa/pkg/reader"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+	"github.com/raspi/heksa/pkg/term"
+)
+
+// editBase is the number base of an editable formatter
+type editBase struct {
+	base   int // 0 = character
+	digits int // Digits per byte
+}
+
+// Formatters which can be used for overwriting bytes in edit mode
+var editableFormatters = map[string]editBase{
+	`hex`: {base: 16, digits: 2},
+	`dec`: {base: 10, digits: 3},
+	`oct`: {base: 8, digits: 3},
+	`bit`: {base: 2, digits: 8},
+	`asc`: {base: 0, digits: 1},
+}
+
+// getEditableFormatterList lists editable formatters for help
+func getEditableFormatterList() (names []string) {
+	for s := range editableFormatters {
+		names = append(names, s)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// backupFileName returns name of the backup file written when saving with --backup
+func backupFileName(name string) string {
+	return name + `.bak`
+}
+
+// startEditing enters edit mode with cursor on the first visible byte
+func (pg *pager) startEditing() {
+	if pg.end == pg.start {
+		pg.message = `nothing to edit`
+		return
+	}
+
+	pg.editFormatter = 0
+	if !pg.nextEditableFormatter() {
+		pg.message = `none of the formatters can be edited, use one of: ` + strings.Join(getEditableFormatterList(), `, `)
+		return
+	}
+
+	pg.editing = true
+	pg.pending = ``
+	pg.cursor = pg.top
+}
+
+// nextEditableFormatter moves cursor to the next editable formatter starting from the current one.
+// false is returned if there are no editable formatters.
+func (pg *pager) nextEditableFormatter() bool {
+	count := len(pg.p.formatterNames)
+
+	for i := 0; i < count; i++ {
+		idx := (pg.editFormatter + i) % count
+		if _, ok := editableFormatters[pg.p.formatterNames[idx]]; ok {
+			pg.editFormatter = idx
+			return true
+		}
+	}
+
+	return false
+}
+
+// lineCursor returns cursor for given line if the cursor is on it
+func (pg *pager) lineCursor(l reader.Line) *base.Cursor {
+	if !pg.editing || pg.cursor < l.Offset || pg.cursor >= l.Offset+uint64(len(l.Data)) {
+		return nil
+	}
+
+	return &base.Cursor{
+		Formatter: pg.editFormatter,
+		Index:     int(pg.cursor - l.Offset),
+		On:        color.SetReverseOn,
+		Off:       color.SetReverseOff,
+	}
+}
+
+// moveCursor moves cursor forward (positive) or backward (negative) but keeps it in the displayed range
+func (pg *pager) moveCursor(amount int64) {
+	pg.pending = ``
+
+	switch {
+	case amount < 0 && uint64(-amount) > pg.cursor-pg.start:
+		pg.cursor = pg.start
+	case amount > 0 && uint64(amount) >= pg.end-pg.cursor:
+		pg.cursor = pg.end - 1
+	default:
+		pg.cursor = uint64(int64(pg.cursor) + amount)
+	}
+
+	pg.show(pg.cursor)
+}
+
+// handleEditKey runs the command of given key in edit mode
+func (pg *pager) handleEditKey(k term.Key) (quit bool, err error) {
+	width := int64(pg.p.fg.Width)
+	page := int64(pg.lines()) * width
+
+	switch k.Type {
+	case term.KeyEscape:
+		if pg.pending != `` {
+			pg.pending = ``
+		} else {
+			pg.editing = false
+		}
+	case term.KeyRight:
+		pg.moveCursor(1)
+	case term.KeyLeft:
+		pg.moveCursor(-1)
+	case term.KeyDown:
+		pg.moveCursor(width)
+	case term.KeyUp:
+		pg.moveCursor(-width)
+	case term.KeyPageDown:
+		pg.moveCursor(page)
+	case term.KeyPageUp:
+		pg.moveCursor(-page)
+	case term.KeyHome:
+		pg.moveCursor(-int64(pg.cursor - pg.alignDown(pg.cursor)))
+	case term.KeyEnd:
+		pg.moveCursor(width - 1 - int64(pg.cursor-pg.alignDown(pg.cursor)))
+	case term.KeyTab:
+		pg.pending = ``
+		pg.editFormatter++
+		pg.nextEditableFormatter()
+	case term.KeyBackspace:
+		if pg.pending != `` {
+			pg.pending = pg.pending[0 : len(pg.pending)-1]
+		} else {
+			pg.moveCursor(-1)
+		}
+	case term.KeyEnter:
+		if pg.pending != `` {
+			pg.writePending()
+		}
+	case term.KeyCtrl:
+		switch k.Rune {
+		case 'c':
+			return pg.confirmQuit(), nil
+		case 'z':
+			pg.undo()
+		case 'y':
+			pg.redo()
+		case 's':
+			pg.save()
+		}
+	case term.KeyRune:
+		pg.typeRune(k.Rune)
+	}
+
+	return false, nil
+}
+
+// typeRune handles character typed in edit mode
+func (pg *pager) typeRune(c rune) {
+	eb := editableFormatters[pg.p.formatterNames[pg.editFormatter]]
+
+	if eb.base == 0 {
+		// Character
+		if c > 0x7F {
+			pg.message = fmt.Sprintf(`%q is not an ASCII character`, c)
+			return
+		}
+
+		pg.overwrite(byte(c))
+		return
+	}
+
+	_, err := strconv.ParseUint(string(c), eb.base, 8)
+	if err != nil {
+		pg.message = fmt.Sprintf(`%q is not a digit in base %d`, c, eb.base)
+		return
+	}
+
+	pg.pending += string(c)
+
+	if len(pg.pending) == eb.digits {
+		pg.writePending()
+	}
+}
+
+// writePending writes typed digits to the byte under cursor
+func (pg *pager) writePending() {
+	eb := editableFormatters[pg.p.formatterNames[pg.editFormatter]]
+
+	value, err := strconv.ParseUint(pg.pending, eb.base, 8)
+	if err != nil {
+		pg.message = fmt.Sprintf(`invalid byte %v: doesn't fit in a byte`, pg.pending)
+		pg.pending = ``
+		return
+	}
+
+	pg.overwrite(byte(value))
+}
+
+// overwrite writes byte under cursor and moves cursor forward
+func (pg *pager) overwrite(b byte) {
+	pg.pending = ``
+
+	err := pg.buffer.Overwrite(pg.cursor, []byte{b})
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	pg.moveCursor(1)
+}
+
+// undo reverts the latest change
+func (pg *pager) undo() {
+	pg.pending = ``
+
+	offset, ok := pg.buffer.Undo()
+	if !ok {
+		pg.message = `nothing to undo`
+		return
+	}
+
+	pg.showChange(offset)
+}
+
+// redo applies the latest undone change again
+func (pg *pager) redo() {
+	pg.pending = ``
+
+	offset, ok := pg.buffer.Redo()
+	if !ok {
+		pg.message = `nothing to redo`
+		return
+	}
+
+	pg.showChange(offset)
+}
+
+// showChange scrolls to undone or redone change
+func (pg *pager) showChange(offset uint64) {
+	if pg.editing {
+		pg.cursor = offset
+	}
+
+	pg.show(offset)
+}
+
+// save writes changes to the file and starts reading the saved file
+func (pg *pager) save() {
+	if !pg.buffer.IsModified() {
+		pg.message = `no changes`
+		return
+	}
+
+	backup := ``
+	if pg.p.backup {
+		backup = backupFileName(pg.in.name)
+	}
+
+	err := edit.Save(pg.buffer, pg.in.name, backup)
+	if err != nil {
+		pg.message = fmt.Sprintf(`save failed: %v`, err)
+		return
+	}
+
+	// Old file was replaced, read the saved one
+	f, err := os.Open(pg.in.name)
+	if err != nil {
+		pg.message = fmt.Sprintf(`couldn't open saved file: %v`, err)
+		return
+	}
+
+	_ = pg.buffer.Close()
+
+	pg.buffer = edit.New(f, pg.buffer.Size())
+	pg.in.source = pg.buffer
+
+	err = pg.resetReader()
+	if err != nil {
+		pg.message = err.Error()
+		return
+	}
+
+	pg.message = `saved ` + pg.in.name
+	if backup != `` {
+		pg.message += `, previous version is ` + backup
+	}
+}
+
+// confirmQuit asks for confirmation if there are unsaved changes
+func (pg *pager) confirmQuit() bool {
+	if !pg.buffer.IsModified() {
+		return true
+	}
+
+	s, ok := pg.prompt(`Quit without saving changes? (y/n) `, ``)
+
+	return ok && strings.EqualFold(strings.TrimSpace(s), `y`)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/output"
+	"github.com/raspi/heksa/pkg/output/jsonOutput"
+)
+
+// runJSON dumps input as JSON or NDJSON records
+func runJSON(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+
+	w, closeOutput := openOutput(p)
+	jw := jsonOutput.New(w, p.outputFormat == output.FormatNDJSON)
+
+	// Relative offset is not available for STDIN
+	printRelative := p.printRelative && in.filesize != -1
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		rec := jsonOutput.Line{
+			Offset: l.Offset,
+			Bytes:  make([]int, len(l.Data)),
+			Cells:  make(map[string][]string),
+		}
+
+		if printRelative {
+			relative := l.RelativeOffset
+			rec.RelativeOffset = &relative
+		}
+
+		for i, b := range l.Data {
+			rec.Bytes[i] = int(b)
+		}
+
+		for i, cells := range p.fg.Cells(l.Data) {
+			rec.Cells[p.formatterNames[i]] = cells
+		}
+
+		err = jw.Line(rec)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error writing output: %v`, err)
+			os.Exit(1)
+		}
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	err = jw.Close(r.GetReadBytes())
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error writing output: %v`, err)
+		os.Exit(1)
+	}
+
+	closeOutput()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -8,13 +9,24 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/DavidGamba/go-getoptions"
 	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/compat"
+	"github.com/raspi/heksa/pkg/export"
+	"github.com/raspi/heksa/pkg/extract"
+	"github.com/raspi/heksa/pkg/find"
+	"github.com/raspi/heksa/pkg/output"
+	"github.com/raspi/heksa/pkg/overview"
 	"github.com/raspi/heksa/pkg/reader"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+	metaFormatters "github.com/raspi/heksa/pkg/reader/metaFormatters/base"
 	offFormatters "github.com/raspi/heksa/pkg/reader/offsetFormatters/base"
+	"github.com/raspi/heksa/pkg/regions"
+	"github.com/raspi/heksa/pkg/stats"
 	"github.com/raspi/heksa/pkg/units"
+	"github.com/raspi/heksa/pkg/visual"
 )
 
 var (
@@ -31,11 +43,128 @@ const (
 
 // These color group names MUST exist in config
 var requiredColorGroupNames = []string{
-	`LineEven`, `LineOdd`, `Splitter`, `Offset`, `Padding`, `Default`, `Special`, `Highlight`,
+	`LineEven`, `LineOdd`, `Splitter`, `Offset`, `Padding`, `Default`, `Special`, `Highlight`, `Diff`, `Match`, `Changed`,
+	`FloatNaN`, `FloatInf`, `FloatDenormal`,
+	`MetaLow`, `MetaMedium`, `MetaHigh`,
+}
+
+// Byte color groups which are considered to be printable text
+var printableColorGroupNames = []string{
+	`Space`, `Printable`, `Number`, `UpperAlpha`, `LowerAlpha`,
+}
+
+// Different modes of operation
+type runMode uint8
+
+const (
+	modeDump        runMode = iota // Dump single file or STDIN (default)
+	modeDiff                       // Compare two files side by side
+	modeCompare                    // Compare many files at the same offsets
+	modeFind                       // Highlight pattern matches
+	modeSearch                     // List offsets of typed value matches
+	modeStrings                    // Extract runs of printable characters
+	modeReverse                    // Convert text dump back to binary
+	modeCompat                     // Output in the layout of another tool
+	modeExport                     // Output as source code array
+	modeImage                      // Render bytes as pixels to PNG file
+	modeOverview                   // Map of chunk summaries
+	modeStats                      // Byte statistics report
+	modeRegions                    // Map of regions classified by entropy
+	modeInspect                    // Every interpretation of bytes at one offset
+	modeInteractive                // Full screen view
+)
+
+// input is a file or STDIN to read from
+type input struct {
+	source   io.ReadSeekCloser
+	filesize int64  // -1 if unknown (STDIN, pipes, etc)
+	name     string // File name
+}
+
+// params contains parsed command line arguments
+type params struct {
+	mode           runMode
+	inputs         []input // First one is the main input, rest are used by multi-file modes
+	offsetViewer   []reader.OffsetFormatter
+	colorGroupings map[string]string
+	palette        [256]string // Color for each byte
+	byteGroups     [256]string // Color group name for each byte
+	limit          uint64
+	fg             base.FormatterGroup
+	printRelative  bool
+	diffAlign      bool // Detect inserted and deleted bytes when comparing
+	diffHunks      bool // Print only machine-readable list of differing hunks
+	findPattern    find.Pattern
+	context        int // Lines of context around matches, -1 = print all lines
+	encodings      []extract.Encoding
+	minLength      int    // Minimum length of extracted strings
+	output         string // Output file, empty = STDOUT
+	seek           int64  // Parsed --seek or --inspect offset
+	compatProfile  compat.Profile
+	compatColor    bool // Use colors in compatibility output
+	exportLanguage export.Language
+	exportName     string // Variable name, empty = derive from file name
+	exportComment  bool   // Add ASCII comment column to exported source code
+	outputFormat   output.Format
+	formatterNames []string // Names of byte formatters as given in --format
+	splitterSize   uint8    // Visual splitter every N bytes
+	imageFile      string   // PNG file to write
+	imageWidth     int      // Pixels (bytes) per image row
+	imageMode      visual.Mode
+	imageLayout    visual.Layout
+	imageTerminal  bool                   // Draw image to terminal
+	overviewChunk  uint64                 // Bytes per overview character, 0 = automatic
+	statsNGram     int                    // Length of counted byte sequences
+	statsTop       int                    // Count of most frequent n-grams printed
+	metaViewer     []reader.MetaFormatter // Metadata columns
+	regionsWindow  uint64                 // Bytes per classified window
+	backup         bool                   // Keep previous version of the file when saving in interactive mode
+	localTime      bool                   // Print timestamp formatters in local time instead of UTC
+}
+
+// openFile opens a file and seeks to given offset. Negative offset seeks from the end of the file.
+func openFile(fpath string, startOffset int64) (in input, err error) {
+	fhandle, err := os.Open(fpath)
+	if err != nil {
+		return in, fmt.Errorf(`error opening file: %w`, err)
+	}
+
+	fi, err := fhandle.Stat()
+	if err != nil {
+		return in, fmt.Errorf(`error stat'ing file: %w`, err)
+	}
+
+	if fi.IsDir() {
+		return in, fmt.Errorf(`error: %v is directory`, fpath)
+	}
+
+	// Seek to given offset
+	if startOffset > 0 {
+		_, err = fhandle.Seek(startOffset, io.SeekCurrent)
+	} else if startOffset < 0 {
+		_, err = fhandle.Seek(startOffset, io.SeekEnd)
+	}
+
+	if err != nil {
+		return in, fmt.Errorf(`couldn't seek to %v: %w`, startOffset, err)
+	}
+
+	in = input{
+		source:   fhandle,
+		filesize: fi.Size(),
+		name:     fpath,
+	}
+
+	if !fi.Mode().IsRegular() {
+		// Not a regular file, so file size is unknown
+		in.filesize = -1
+	}
+
+	return in, nil
 }
 
 // Parse command line arguments
-func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatter, colorGroupings map[string]string, limit uint64, filesize int64, fg base.FormatterGroup, printRelative bool) {
+func getParams() (p params) {
 	opt := getoptions.New()
 
 	opt.HelpSynopsisArgs(`<filename> or STDIN`)
@@ -59,6 +188,11 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		),
 	)
 
+	argMeta := opt.StringOptional(`meta`, ``,
+		opt.ArgName(`fmt1,fmt2,..`),
+		opt.Description(`Metadata columns computed from each line: `+strings.Join(reader.GetMetaViewerList(), `, `)+`. See NOTES.`),
+	)
+
 	argPrintRelativeOffset := opt.Bool(`print-relative-offset`, false,
 		opt.Alias(`r`),
 		opt.Description(`Print relative offset(s) starting from 0 (file only)`),
@@ -94,6 +228,171 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		opt.Description(`Insert visual splitter every N bytes. Zero (0) disables.`),
 	)
 
+	argDiff := opt.Bool(`diff`, false,
+		opt.Description(`Compare two files side by side. See NOTES.`),
+	)
+
+	argDiffAlign := opt.Bool(`diff-align`, false,
+		opt.Description(`Detect inserted and deleted bytes when comparing files`),
+	)
+
+	argDiffHunks := opt.Bool(`diff-hunks`, false,
+		opt.Description(`Print machine-readable list of differing hunks instead of dump (implies --diff-align)`),
+	)
+
+	argCompare := opt.Bool(`compare`, false,
+		opt.Description(`Compare two or more files at the same offsets. See NOTES.`),
+	)
+
+	argFind := opt.String(`find`, ``,
+		opt.ArgName(`hex pattern`),
+		opt.Description(`Highlight matches of hex byte pattern, '?' is a wildcard. See NOTES.`),
+	)
+
+	argSearch := opt.String(`search`, ``,
+		opt.ArgName(`type:value`),
+		opt.Description(`List offsets of typed value, for example u32le:1337, f32:3.5~0.01 or utf16le:"Player". See NOTES.`),
+	)
+
+	argSearchDump := opt.Bool(`search-dump`, false,
+		opt.Description(`Dump lines of --search matches instead of listing offsets`),
+	)
+
+	argStrings := opt.Bool(`strings`, false,
+		opt.Description(`Print runs of printable characters with offsets. See NOTES.`),
+	)
+
+	argStringsMin := opt.IntOptional(`strings-min`, 4,
+		opt.ArgName(`length`),
+		opt.Description(`Minimum length of printed strings`),
+	)
+
+	argStringsEncoding := opt.StringOptional(`strings-encoding`, `ascii`,
+		opt.ArgName(`enc1,enc2,..`),
+		opt.Description(`One or multiple of: `+strings.Join(extract.GetEncodingList(), `, `)),
+	)
+
+	argReverse := opt.Bool(`reverse`, false,
+		opt.Description(`Convert heksa, xxd, od -Ax -tx1 or hexdump -C dump back to binary. See NOTES.`),
+	)
+
+	argOutput := opt.String(`output`, ``,
+		opt.ArgName(`file`),
+		opt.Description(`Write output to file instead of STDOUT`),
+	)
+
+	argCompat := opt.String(`compat`, ``,
+		opt.ArgName(`tool`),
+		opt.Description(`Output in the exact layout of: `+strings.Join(compat.GetProfileList(), `, `)+`. See NOTES.`),
+	)
+
+	argCompatColor := opt.Bool(`compat-color`, false,
+		opt.Description(`Use colors with --compat`),
+	)
+
+	argExport := opt.String(`export`, ``,
+		opt.ArgName(`language`),
+		opt.Description(`Output as source code array: `+strings.Join(export.GetLanguageList(), `, `)+`. See NOTES.`),
+	)
+
+	argExportName := opt.String(`export-name`, ``,
+		opt.ArgName(`name`),
+		opt.Description(`Variable name used with --export`),
+	)
+
+	argExportComment := opt.Bool(`export-comment`, false,
+		opt.Description(`Add comment column with ASCII characters to --export output`),
+	)
+
+	argOutputFormat := opt.String(`output-format`, `text`,
+		opt.ArgName(`fmt`),
+		opt.Description(`One of: `+strings.Join(output.GetFormatList(), `, `)+`. See NOTES.`),
+	)
+
+	argHTML := opt.Bool(`html`, false,
+		opt.Description(`Output as self-contained HTML page, same as --output-format html`),
+	)
+
+	argImage := opt.String(`image`, ``,
+		opt.ArgName(`file.png`),
+		opt.Description(`Render every byte as a pixel and save as PNG image. See NOTES.`),
+	)
+
+	argImageWidth := opt.IntOptional(`image-width`, 256,
+		opt.ArgName(`pixels`),
+		opt.Description(`Image width, count of bytes per row`),
+	)
+
+	argImageMode := opt.String(`image-mode`, `palette`,
+		opt.ArgName(`mode`),
+		opt.Description(`Pixel color with --image: `+strings.Join(visual.GetModeList(), `, `)),
+	)
+
+	argImageLayout := opt.String(`image-layout`, `linear`,
+		opt.ArgName(`layout`),
+		opt.Description(`Pixel layout with --image: `+strings.Join(visual.GetLayoutList(), `, `)+`. See NOTES.`),
+	)
+
+	argImageTerminal := opt.Bool(`image-terminal`, false,
+		opt.Description(`Draw image to terminal with half-block characters, --image file is optional`),
+	)
+
+	argOverview := opt.Bool(`overview`, false,
+		opt.Description(`Print a map of the file where each character summarizes a chunk. See NOTES.`),
+	)
+
+	argOverviewChunk := opt.StringOptional(`overview-chunk`, `0`,
+		opt.ArgName(`size`),
+		opt.Description(`Bytes per character in --overview (0 = fit on one screen)`),
+	)
+
+	argStats := opt.Bool(`stats`, false,
+		opt.Description(`Print byte statistics: histogram, byte groups, entropy, longest run and n-grams. See NOTES.`),
+	)
+
+	argStatsNGram := opt.IntOptional(`stats-ngram`, 2,
+		opt.ArgName(`length`),
+		opt.Description(fmt.Sprintf(`Length of byte sequences counted by --stats (%d - %d)`, stats.MinNGramSize, stats.MaxNGramSize)),
+	)
+
+	argStatsTop := opt.IntOptional(`stats-top`, 10,
+		opt.ArgName(`count`),
+		opt.Description(`Count of most frequent byte sequences printed by --stats`),
+	)
+
+	argRegions := opt.Bool(`regions`, false,
+		opt.Description(`Print map of regions classified by entropy and byte groups. See NOTES.`),
+	)
+
+	argRegionsWindow := opt.StringOptional(`regions-window`, `512`,
+		opt.ArgName(`size`),
+		opt.Description(`Bytes per classified window in --regions`),
+	)
+
+	argInspect := opt.String(`inspect`, ``,
+		opt.ArgName(`offset`),
+		opt.Description(`Print every interpretation (integers, floats, characters, times, GUID, addresses) of the bytes at offset. See NOTES.`),
+	)
+
+	argInteractive := opt.Bool(`interactive`, false,
+		opt.Alias(`i`),
+		opt.Description(`Full screen view with scrolling, goto and search (file only). See NOTES.`),
+	)
+
+	argBackup := opt.Bool(`backup`, false,
+		opt.Description(`Keep previous version as <file>.bak when saving changes in --interactive`),
+	)
+
+	argLocalTime := opt.Bool(`local-time`, false,
+		opt.Description(`Print timestamp formatters in local time instead of UTC`),
+	)
+
+	argContext := opt.IntOptional(`context`, 0,
+		opt.Alias("C"),
+		opt.ArgName(`lines`),
+		opt.Description(`Print only lines containing matches with N lines of context`),
+	)
+
 	remainingArgs, err := opt.Parse(os.Args[1:])
 
 	if opt.Called("help") {
@@ -110,6 +409,86 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		_, _ = fmt.Fprintln(os.Stdout, `      - 'humiec' (IEC: 1024 B) and 'humsi' (SI: 1000 B) displays offset in human form (n KiB/KB)`)
 		_, _ = fmt.Fprintln(os.Stdout, `    - Formatters:`)
 		_, _ = fmt.Fprintln(os.Stdout, `      - 'blk' can be used to print simple color blocks which helps to visualize where data vs. human readable strings are`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'u16le', 'i32be', 'x64le', etc. print 2, 4 or 8 bytes per cell as unsigned (u), signed (i) or hexadecimal (x) number`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'f16le', 'bf16be', 'f32le', 'f64be', etc. print floats, NaN, infinity and denormal values have their own colors`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'unix32le', 'unixmsbe', 'filetimele', 'dosle', 'hfsbe', 'gpsle', etc. print timestamps in ISO 8601 format (UTC unless --local-time)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Timestamps before 1980 or after 2099 are printed with the Special color`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'ipv4' (4 bytes), 'ipv6' (16), 'mac' (6), 'uuid' (16, RFC 4122 order) and 'guid' (16, Microsoft mixed-endian order) print addresses and identifiers`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Width must be a multiple of the cell size, default width is 16 rounded up to a multiple of every cell size ('hex,mac' = 48)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Incomplete cell at the end of the data is shown with missing bytes as zeros in padding color`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Meta formatters:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Columns are printed after the formatters and before the right side offset`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'ent' = entropy in bits per byte, 'prn' = percentage of printable characters, 'sum' = 8-bit sum, 'xor' = XOR of bytes`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'ent' and 'prn' are colored by value with MetaLow, MetaMedium and MetaHigh colors`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Diff:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - '--diff' takes two files and compares bytes at the same offsets, seek and limit are applied to both`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Identical lines are collapsed`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - '--diff-align' realigns the files after inserted or deleted bytes, both files are read into memory`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - '--diff-hunks' prints tab separated list: type, left offset, left length, right offset, right length`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Compare:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - '--compare' prints one row per file for each offset, columns where files disagree are highlighted`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - If files start at different offsets (seeking from the end), every row has its own offset`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Only the first offset formatter is used`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Find:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Pattern is hex bytes such as '7f 45 4c 46 ?? 01', '?' matches any nibble (for example '4?')`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Matches spanning multiple lines are found also when reading from STDIN`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Search:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Types: `+strings.Join(find.GetTypedValueList(), `, `))
+		_, _ = fmt.Fprintln(os.Stdout, `      - Little endian is used if endianness is omitted (u32:1337)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Integers support same prefixes and units as seek and limit, floats support tolerance (f64:1.5~0.001)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - '--search-dump' prints matching lines highlighted, use --context for more lines`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Strings:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Printable characters are the byte groups `+strings.Join(printableColorGroupNames, `, `))
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'utf8' also accepts printable multi-byte characters, 'utf16le' and 'utf16be' accept only ASCII range`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Reverse:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - heksa dumps must use 'hex' formatter and 'hex' offsets or no offsets`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - With --output existing file is patched in place at the offsets of the dump (file is not truncated)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - --seek is added to the offsets when writing to file, STDOUT output starts from the first offset of the dump`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Compat:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'xxd' = xxd, 'hexdump' = hexdump -C, 'od' = od -Ax -tx1`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - --width, --seek and --limit are used, other formatting options are ignored`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Export:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - --width sets the count of array elements per line, --seek and --limit select the range`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Default variable name is derived from the file name like 'xxd -i' does ('data' for STDIN)`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Output format:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'json' prints {"lines":[...],"summary":{...}}, 'ndjson' prints one record per line and summary record last`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Records have offset, relative_offset (with --print-relative-offset), bytes and cells keyed by formatter name, so each formatter can be given only once`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'html' has a CSS class for each color group and hovering a byte shows its offset and value in every formatter`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'svg' renders the selected range as an image for documents, repeated lines are not collapsed`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - JSON, HTML and SVG are written to --output file if given`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Image:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'palette' uses the byte colors, 'gray' uses byte value as brightness`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - 'entropy' colors each block of %d bytes from black (low) through blue, magenta and red to yellow (high)\n", visual.EntropyBlockSize)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Set --image-width to the record size of the file format to see records as stripes`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'hilbert' keeps nearby bytes near each other, --image-width is the maximum side (power of two) and a pixel averages many bytes if needed`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - 'digraph' is 256x256 plot of how often byte X is followed by byte Y, --image-mode is ignored`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - --image-terminal scales the image to the terminal width, COLUMNS environment variable is used if STDOUT isn't a terminal (default 80)`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - Image is drawn while reading, 'linear' is limited to %d bytes (4 bytes of memory per byte), use --limit or 'hilbert' for larger inputs\n", maxLinearImageBytes)
+		_, _ = fmt.Fprintln(os.Stdout, `      - STDIN is read into memory for 'linear' and 'hilbert', because they need the size of the input`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Overview:`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - Each row has %d chunks, count of braille dots is the entropy of the chunk in bits\n", overview.DefaultChunkPerRow)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Colors: NullEOF = mostly null bytes, Printable = text, UpperByte = high entropy, Default = other`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - STDIN uses %d byte chunks by default\n", overview.DefaultChunkSize)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Stats:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Use --output-format json for JSON report`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - At most %d distinct n-grams are counted, after that the least frequent are dropped and counts are approximate (never too low)\n", stats.MaxNGramKeys)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Regions:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Windows are classified as padding, text, low entropy, mixed (code/data) or compressed/encrypted`)
+		_, _ = fmt.Fprintf(os.Stdout, "      - Window slides by 1/%d of its size and each step gets the class of the window centered on it\n", regions.StepsPerWindow)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Boundaries are accurate to about half of the window, short regions of other classes can appear at the boundaries`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Consecutive steps of the same class are merged, a single differing step between them is ignored`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Inspect:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Offset uses the same syntax as --seek, negative offset is from the end of the file`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Up to 16 bytes are read, interpretations which need more bytes than available are shown as '-'`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - GUID is Microsoft mixed-endian order, UUID is RFC 4122 order, times are UTC`)
+		_, _ = fmt.Fprintln(os.Stdout, `    - Interactive:`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Press 'h' for keys, only the visible lines are read from the file`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Goto (':') uses the same syntax as --seek, search ('/' and '?') accepts 'x:<hex pattern>', '<type>:<value>' or text`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Formatters ('f') and width ('w', '<', '>') can be changed while viewing`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Edit mode ('e') overwrites bytes in hex, dec, oct, bit or asc formatter, changes are kept in memory until saved (Ctrl-S)`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Saving writes a temporary file which then replaces the file, so the file is never left half written`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Symbolic links are followed, hard linked files and files whose owner can't be kept are overwritten in place`)
+		_, _ = fmt.Fprintln(os.Stdout, `      - Supported only on Linux`)
 		_, _ = fmt.Fprintln(os.Stdout)
 		_, _ = fmt.Fprintln(os.Stdout, `EXAMPLES:`)
 		_, _ = fmt.Fprintln(os.Stdout, `    heksa -f hex,asc,bit foo.dat`)
@@ -120,6 +499,27 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		_, _ = fmt.Fprintln(os.Stdout, `    heksa -s 0b1010 foo.dat`)
 		_, _ = fmt.Fprintln(os.Stdout, `    heksa -s 4321KiB foo.dat`)
 		_, _ = fmt.Fprintln(os.Stdout, `    heksa -w 8 foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa -f hex,mac foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --meta ent,crc32 foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --diff old.bin new.bin`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --diff --diff-align old.bin new.bin`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --compare -l 256 dump1.bin dump2.bin dump3.bin`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --find "7f 45 4c 46 ?? 01" -C 2 foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --search u16be:1337 foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --strings --strings-min 6 --strings-encoding ascii,utf16le foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --reverse --output foo.dat foo.txt`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --compat hexdump foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --export rust --export-name FIRMWARE --export-comment fw.bin`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --output-format ndjson -f hex,dec -l 1KiB foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --html -f hex,asc,dec --output foo.html foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --output-format svg -s 0x200 -l 64 --output header.svg foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --image foo.png --image-mode entropy foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --image-terminal --image-layout hilbert --image-mode entropy foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --overview --overview-chunk 64KiB disk.img`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --stats --stats-ngram 4 --output-format json foo.dat`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --regions --regions-window 4KiB firmware.bin`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa --inspect 0x3c foo.exe`)
+		_, _ = fmt.Fprintln(os.Stdout, `    heksa -i -f hex,asc,bit disk.img`)
 		_, _ = fmt.Fprintln(os.Stdout, `    echo "test" | heksa`)
 		os.Exit(0)
 	} else if opt.Called("version") {
@@ -138,7 +538,7 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		_, _ = fmt.Fprintf(os.Stderr, `error parsing limit: %v`, err)
 		os.Exit(1)
 	}
-	limit = uint64(limitTmp)
+	p.limit = uint64(limitTmp)
 
 	startOffset, err := units.Parse(strings.Replace(*argSeek, `\`, ``, -1))
 	if err != nil {
@@ -157,138 +557,736 @@ func getParams() (source io.ReadSeekCloser, offsetViewer []reader.OffsetFormatte
 		os.Exit(1)
 	}
 
-	offsetViewer, err = reader.GetOffsetFormatters(strings.Split(*argOffset, `,`))
+	p.offsetViewer, err = reader.GetOffsetFormatters(strings.Split(*argOffset, `,`))
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, `error getting offset formatter: %v`, err)
 		os.Exit(1)
 	}
 
-	displays, err := reader.GetViewers(strings.Split(*argFormat, `,`))
+	p.metaViewer, err = reader.GetMetaFormatters(strings.Split(*argMeta, `,`))
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, `error getting formatter: %v`, err)
+		_, _ = fmt.Fprintf(os.Stderr, `error getting meta formatter: %v`, err)
 		os.Exit(1)
 	}
 
-	stat, err := os.Stdin.Stat()
+	displays, err := reader.GetViewers(strings.Split(*argFormat, `,`))
 	if err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, `couldn't stat stdin: %v`, err)
+		_, _ = fmt.Fprintf(os.Stderr, `error getting formatter: %v`, err)
 		os.Exit(1)
 	}
 
-	if (stat.Mode() & os.ModeCharDevice) == 0 {
-		// Stdin has data
-		source = os.Stdin
-		filesize = -1
-	} else {
-		// Read file
-		if len(remainingArgs) != 1 {
-			_, _ = fmt.Fprintln(os.Stderr, `error: no file given as argument, see --help`)
+	p.diffHunks = *argDiffHunks
+	p.diffAlign = *argDiffAlign || p.diffHunks
+
+	if *argDiff || p.diffAlign {
+		p.mode = modeDiff
+
+		if len(remainingArgs) != 2 {
+			_, _ = fmt.Fprintln(os.Stderr, `error: diff needs exactly two files as arguments, see --help`)
+			os.Exit(1)
+		}
+	}
+
+	if *argCompare {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --compare can't be used with --diff`)
+			os.Exit(1)
+		}
+
+		p.mode = modeCompare
+
+		if len(remainingArgs) < 2 {
+			_, _ = fmt.Fprintln(os.Stderr, `error: compare needs two or more files as arguments, see --help`)
+			os.Exit(1)
+		}
+	}
+
+	if opt.Called(`find`) {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --find can't be used with --diff or --compare`)
+			os.Exit(1)
+		}
+
+		p.mode = modeFind
+
+		p.findPattern, err = find.ParseHex(*argFind)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error parsing find pattern: %v`, err)
+			os.Exit(1)
+		}
+	}
+
+	if opt.Called(`search`) {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --search can't be used with --diff, --compare or --find`)
+			os.Exit(1)
+		}
+
+		p.mode = modeSearch
+
+		p.findPattern, err = find.ParseTypedValue(*argSearch)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error parsing search value: %v`, err)
+			os.Exit(1)
+		}
+
+		if *argSearchDump {
+			// Dump matches with the same highlighting as --find
+			p.mode = modeFind
+		}
+	}
+
+	if *argStrings {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --strings can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeStrings
+
+		p.encodings, err = extract.GetEncodings(strings.Split(*argStringsEncoding, `,`))
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error getting strings encoding: %v`, err)
+			os.Exit(1)
+		}
+
+		p.minLength = *argStringsMin
+		if p.minLength < 1 {
+			_, _ = fmt.Fprint(os.Stderr, `strings minimum length must be > 0`)
+			os.Exit(1)
+		}
+	}
+
+	if *argReverse {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --reverse can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeReverse
+		p.seek = startOffset
+
+		// Input dump itself is read from the beginning
+		startOffset = 0
+	}
+
+	if opt.Called(`compat`) {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --compat can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeCompat
+
+		p.compatProfile, err = compat.GetProfile(*argCompat)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error getting compat profile: %v`, err)
+			os.Exit(1)
+		}
+
+		p.compatColor = *argCompatColor
+	}
+
+	if opt.Called(`export`) {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --export can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeExport
+
+		p.exportLanguage, err = export.GetLanguage(*argExport)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error getting export language: %v`, err)
+			os.Exit(1)
+		}
+
+		p.exportName = *argExportName
+		p.exportComment = *argExportComment
+	}
+
+	if opt.Called(`image`) || *argImageTerminal {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --image can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeImage
+		p.imageFile = *argImage
+		p.imageTerminal = *argImageTerminal
+
+		if p.imageFile == `` && !p.imageTerminal {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --image needs a file name`)
+			os.Exit(1)
+		}
+
+		p.imageWidth = *argImageWidth
+		if p.imageWidth < 1 {
+			_, _ = fmt.Fprint(os.Stderr, `image width must be > 0`)
+			os.Exit(1)
+		}
+
+		p.imageMode, err = visual.GetMode(*argImageMode)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error getting image mode: %v`, err)
+			os.Exit(1)
+		}
+
+		p.imageLayout, err = visual.GetLayout(*argImageLayout)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error getting image layout: %v`, err)
 			os.Exit(1)
 		}
+	}
 
-		fpath := remainingArgs[0]
+	if *argOverview {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --overview can't be used with other modes`)
+			os.Exit(1)
+		}
 
-		fhandle, err := os.Open(fpath)
+		p.mode = modeOverview
+
+		chunkTmp, err := units.Parse(*argOverviewChunk)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, `error opening file: %v`, err)
+			_, _ = fmt.Fprintf(os.Stderr, `error parsing overview chunk size: %v`, err)
+			os.Exit(1)
+		}
+
+		if chunkTmp < 0 {
+			_, _ = fmt.Fprint(os.Stderr, `overview chunk size must be >= 0`)
+			os.Exit(1)
+		}
+
+		p.overviewChunk = uint64(chunkTmp)
+	}
+
+	if *argStats {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --stats can't be used with other modes`)
 			os.Exit(1)
 		}
 
-		fi, err := fhandle.Stat()
+		p.mode = modeStats
+
+		p.statsNGram = *argStatsNGram
+		if p.statsNGram < stats.MinNGramSize || p.statsNGram > stats.MaxNGramSize {
+			_, _ = fmt.Fprintf(os.Stderr, `n-gram length must be %d - %d`, stats.MinNGramSize, stats.MaxNGramSize)
+			os.Exit(1)
+		}
+
+		p.statsTop = *argStatsTop
+		if p.statsTop < 0 {
+			_, _ = fmt.Fprint(os.Stderr, `n-gram count must be >= 0`)
+			os.Exit(1)
+		}
+	}
+
+	if *argRegions {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --regions can't be used with other modes`)
+			os.Exit(1)
+		}
+
+		p.mode = modeRegions
+
+		windowTmp, err := units.Parse(*argRegionsWindow)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, `error stat'ing file: %v`, err)
+			_, _ = fmt.Fprintf(os.Stderr, `error parsing regions window size: %v`, err)
 			os.Exit(1)
 		}
 
-		if fi.IsDir() {
-			_, _ = fmt.Fprintf(os.Stderr, `error: %v is directory`, fpath)
+		if windowTmp < 1 {
+			_, _ = fmt.Fprint(os.Stderr, `regions window size must be > 0`)
 			os.Exit(1)
 		}
 
-		// Seek to given offset
-		if startOffset > 0 {
-			_, err = fhandle.Seek(startOffset, io.SeekCurrent)
-		} else if startOffset < 0 {
-			_, err = fhandle.Seek(startOffset, io.SeekEnd)
+		p.regionsWindow = uint64(windowTmp)
+	}
+
+	if opt.Called(`inspect`) {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --inspect can't be used with other modes`)
+			os.Exit(1)
 		}
 
+		if opt.Called(`seek`) {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --inspect can't be used with --seek`)
+			os.Exit(1)
+		}
+
+		p.mode = modeInspect
+
+		startOffset, err = units.Parse(strings.Replace(*argInspect, `\`, ``, -1))
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, `couldn't seek to %v: %v`, startOffset, err)
+			_, _ = fmt.Fprintf(os.Stderr, `error parsing inspect offset: %v`, err)
 			os.Exit(1)
 		}
 
-		filesize = fi.Size()
+		p.seek = startOffset
+	}
 
-		if !fi.Mode().IsRegular() {
-			// Not a regular file, so file size is unknown
-			filesize = -1
+	if *argInteractive {
+		if p.mode != modeDump {
+			_, _ = fmt.Fprintln(os.Stderr, `error: --interactive can't be used with other modes`)
+			os.Exit(1)
 		}
 
-		source = fhandle
+		p.mode = modeInteractive
+		p.backup = *argBackup
 	}
 
-	colorGroupings, err = color.GetColorGroupColorDefaults(strings.NewReader(DefaultGroupColors), requiredColorGroupNames)
+	p.output = *argOutput
+
+	p.outputFormat, err = output.GetFormat(*argOutputFormat)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error getting output format: %v`, err)
+		os.Exit(1)
+	}
+
+	if *argHTML {
+		p.outputFormat = output.FormatHTML
+	}
+
+	switch {
+	case p.outputFormat == output.FormatText, p.mode == modeDump:
+	case p.mode == modeStats && (p.outputFormat == output.FormatJSON || p.outputFormat == output.FormatNDJSON):
+	default:
+		_, _ = fmt.Fprintln(os.Stderr, `error: --output-format can't be used with other modes`)
+		os.Exit(1)
+	}
+
+	p.context = -1
+	if opt.Called(`context`) {
+		if *argContext < 0 {
+			_, _ = fmt.Fprint(os.Stderr, `context must be >= 0`)
+			os.Exit(1)
+		}
+
+		p.context = *argContext
+	} else if p.mode == modeFind && *argSearchDump {
+		// Only matching lines by default
+		p.context = 0
+	}
+
+	switch p.mode {
+	case modeDiff, modeCompare:
+		for _, fpath := range remainingArgs {
+			in, err := openFile(fpath, startOffset)
+			if err != nil {
+				_, _ = fmt.Fprint(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			p.inputs = append(p.inputs, in)
+		}
+	default:
+		stat, err := os.Stdin.Stat()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `couldn't stat stdin: %v`, err)
+			os.Exit(1)
+		}
+
+		if (stat.Mode() & os.ModeCharDevice) == 0 {
+			// Stdin has data
+			p.inputs = append(p.inputs, input{
+				source:   os.Stdin,
+				filesize: -1,
+				name:     `STDIN`,
+			})
+		} else {
+			// Read file
+			if len(remainingArgs) != 1 {
+				_, _ = fmt.Fprintln(os.Stderr, `error: no file given as argument, see --help`)
+				os.Exit(1)
+			}
+
+			in, err := openFile(remainingArgs[0], startOffset)
+			if err != nil {
+				_, _ = fmt.Fprint(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			p.inputs = append(p.inputs, in)
+		}
+	}
+
+	p.colorGroupings, err = color.GetColorGroupColorDefaults(strings.NewReader(DefaultGroupColors), requiredColorGroupNames)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, `error loading color group config: %v`, err)
 		os.Exit(1)
 	}
 
-	palette, err := color.GetColors(strings.NewReader(DefaultByteColorGroups), colorGroupings)
+	p.palette, err = color.GetColors(strings.NewReader(DefaultByteColorGroups), p.colorGroupings)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error loading color config: %v`, err)
+		os.Exit(1)
+	}
+
+	p.byteGroups, err = color.GetByteGroups(strings.NewReader(DefaultByteColorGroups))
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, `error loading color config: %v`, err)
 		os.Exit(1)
 	}
 
+	p.formatterNames = strings.Split(*argFormat, `,`)
+
+	if p.outputFormat == output.FormatJSON || p.outputFormat == output.FormatNDJSON {
+		// Cells are keyed by formatter name
+		seen := make(map[string]bool)
+		for _, name := range p.formatterNames {
+			if seen[name] {
+				_, _ = fmt.Fprintf(os.Stderr, "error: formatter %q given more than once, not possible with --output-format %v\n", name, *argOutputFormat)
+				os.Exit(1)
+			}
+
+			seen[name] = true
+		}
+	}
+
+	p.splitterSize = uint8(*argSplitter)
+
+	p.localTime = *argLocalTime
+
+	if !opt.Called(`width`) {
+		width = getDefaultWidth(displays, width)
+	}
+
+	p.fg, err = getFormatterGroup(p, displays, width)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	p.printRelative = *argPrintRelativeOffset
+
+	return p
+}
+
+// getDefaultWidth returns the smallest multiple of width which is also a multiple of the cell size of every formatter
+func getDefaultWidth(displays []reader.ByteFormatter, width uint16) uint16 {
+	for _, f := range displays {
+		mf, ok := reader.GetByteFormatter(f, reader.FormatterColors{}, time.UTC).(base.MultiByteFormatter)
+		if !ok {
+			continue
+		}
+
+		// Least common multiple
+		a, b := int(width), mf.GetByteCount()
+		for b != 0 {
+			a, b = b, a%b
+		}
+
+		width = uint16(int(width) / a * mf.GetByteCount())
+	}
+
+	return width
+}
+
+// getFormatterGroup creates formatter group of given byte formatters with colors from parameters
+func getFormatterGroup(p params, displays []reader.ByteFormatter, width uint16) (fg base.FormatterGroup, err error) {
+	colors := reader.FormatterColors{
+		Highlight:     p.colorGroupings[`Highlight`],
+		Special:       p.colorGroupings[`Special`],
+		UnderlineOn:   color.SetUnderlineOn,
+		UnderlineOff:  color.SetUnderlineOff,
+		FloatNaN:      p.colorGroupings[`FloatNaN`],
+		FloatInf:      p.colorGroupings[`FloatInf`],
+		FloatDenormal: p.colorGroupings[`FloatDenormal`],
+	}
+
+	if p.outputFormat != output.FormatText {
+		// Cells must not contain ANSI codes
+		colors = reader.FormatterColors{}
+	}
+
+	loc := time.UTC
+	if p.localTime {
+		loc = time.Local
+	}
+
 	var formatters []base.ByteFormatter
 	for _, f := range displays {
-		fmter := reader.GetByteFormatter(f, colorGroupings[`Highlight`], colorGroupings[`Special`])
+		fmter := reader.GetByteFormatter(f, colors, loc)
 		if fmter == nil {
-			_, _ = fmt.Fprintf(os.Stderr, `error: unknown formatter %v`, f)
-			os.Exit(1)
+			return fg, fmt.Errorf(`error: unknown formatter %v`, f)
+		}
+
+		if mf, ok := fmter.(base.MultiByteFormatter); ok && int(width)%mf.GetByteCount() != 0 {
+			return fg, fmt.Errorf(`error: width %d is not a multiple of %d bytes used by multi-byte formatter`, width, mf.GetByteCount())
 		}
 
 		formatters = append(formatters, fmter)
 	}
 
-	fGroup := base.New(formatters, palette, colorGroupings[`Splitter`], colorGroupings[`Padding`], width, uint8(*argSplitter))
+	fg = base.New(formatters, p.palette, p.colorGroupings[`Splitter`], p.colorGroupings[`Padding`], width, p.splitterSize)
+	fg.MultiByteColor = p.colorGroupings[`Default`]
+
+	if p.outputFormat != output.FormatText {
+		fg.MultiByteColor = ``
+	}
 
-	return source, offsetViewer, colorGroupings, limit, filesize, fGroup, *argPrintRelativeOffset
+	return fg, nil
 }
 
-func main() {
-	source, offViewer, colorGroupings, limit, filesize, fGroup, printRelative := getParams()
-	usingLimit := limit > 0
+// getInputOffset returns current position of the input, 0 for STDIN
+func getInputOffset(in input) uint64 {
+	if in.filesize == -1 {
+		return 0
+	}
+
+	pos, err := in.source.Seek(0, io.SeekCurrent)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't seek: %v`, err)
+		os.Exit(1)
+	}
 
+	return uint64(pos)
+}
+
+// getOffsetFormatters returns offset formatters selected in parameters for given input
+func getOffsetFormatters(p params, in input) (offormatters []offFormatters.OffsetFormatter) {
 	binfo := offFormatters.BaseInfo{
-		FileSize: filesize,
+		FileSize: in.filesize,
 	}
 
-	var offormatters []offFormatters.OffsetFormatter
-	for _, f := range offViewer {
+	for _, f := range p.offsetViewer {
 		fmter := reader.GetFromOffsetFormatter(f, binfo)
 		offormatters = append(offormatters, fmter)
 	}
 
+	return offormatters
+}
+
+// getLineOffsets returns formatted offsets of a line on the left and right side of the formatters.
+// Order is the same as in the terminal: <offset #1><relative #1><formatters><relative #2><offset #2>
+func getLineOffsets(offsetFormatters []offFormatters.OffsetFormatter, l reader.Line, printRelative bool) (left []string, right []string) {
+	if len(offsetFormatters) > 0 {
+		left = append(left, offsetFormatters[0].Print(l.Offset))
+
+		if printRelative {
+			left = append(left, offsetFormatters[0].Print(l.RelativeOffset))
+		}
+	}
+
+	if len(offsetFormatters) > 1 {
+		if printRelative {
+			right = append(right, offsetFormatters[1].Print(l.RelativeOffset))
+		}
+
+		right = append(right, offsetFormatters[1].Print(l.Offset))
+	}
+
+	return left, right
+}
+
+// openOutput opens file given with --output or STDOUT for writing.
+// Returned function flushes and closes the output.
+func openOutput(p params) (*bufio.Writer, func()) {
+	if p.output == `` {
+		w := bufio.NewWriter(os.Stdout)
+
+		return w, func() {
+			err := w.Flush()
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, `error writing output: %v`, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	f, err := os.Create(p.output)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error opening output file: %v`, err)
+		os.Exit(1)
+	}
+
+	w := bufio.NewWriter(f)
+
+	return w, func() {
+		err := w.Flush()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error writing output: %v`, err)
+			os.Exit(1)
+		}
+
+		err = f.Close()
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `couldn't close output file: %v`, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// newReader creates a new reader for given input with formatters and colors from parameters
+func newReader(p params, in input) *reader.Reader {
+	offormatters := getOffsetFormatters(p, in)
+
+	var metaformatters []metaFormatters.MetaFormatter
+	for _, f := range p.metaViewer {
+		metaformatters = append(metaformatters, reader.GetFromMetaFormatter(f, metaFormatters.BaseInfo{
+			Printable: getPrintableBytes(p.byteGroups),
+		}))
+	}
+
 	colors := reader.ReaderColors{
-		LineOdd:  colorGroupings[`LineOdd`],
-		LineEven: colorGroupings[`LineEven`],
-		Offset:   colorGroupings[`Offset`],
-		Splitter: colorGroupings[`Splitter`],
+		LineOdd:  p.colorGroupings[`LineOdd`],
+		LineEven: p.colorGroupings[`LineEven`],
+		Offset:   p.colorGroupings[`Offset`],
+		Splitter: p.colorGroupings[`Splitter`],
 	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
+	colors.Meta[metaFormatters.LevelNone] = p.colorGroupings[`Offset`]
+	colors.Meta[metaFormatters.LevelLow] = p.colorGroupings[`MetaLow`]
+	colors.Meta[metaFormatters.LevelMedium] = p.colorGroupings[`MetaMedium`]
+	colors.Meta[metaFormatters.LevelHigh] = p.colorGroupings[`MetaHigh`]
+
+	isStdin := in.filesize == -1
 
-	isStdin := filesize == -1
-	if isStdin {
-		printRelative = false
+	return reader.New(in.source, offormatters, metaformatters, colors, p.fg, isStdin, p.printRelative)
+}
+
+// getPrintableBytes returns table of bytes which belong to printable byte color groups
+func getPrintableBytes(byteGroups [256]string) (printable [256]bool) {
+	return getGroupBytes(byteGroups, printableColorGroupNames)
+}
+
+// getGroupBytes returns table of bytes which belong to any of the given byte color groups
+func getGroupBytes(byteGroups [256]string, names []string) (found [256]bool) {
+	for i, group := range byteGroups {
+		for _, name := range names {
+			if group == name {
+				found[i] = true
+			}
+		}
 	}
 
-	r := reader.New(source, offormatters, colors, fGroup, isStdin, printRelative)
+	return found
+}
+
+// repeatedLinesMessage is displayed instead of lines which were collapsed
+func repeatedLinesMessage(count int, width int) string {
+	return "\t" + fmt.Sprintf(`-- last line repeated %[1]d times (%[2]d bytes (0x%04[2]x))`, count, count*width)
+}
 
-	isFirst := true
-	lastData := make([]byte, fGroup.Width)
-	repeatedCount := 0
+// lineCollapser prints lines so that repeated lines are collapsed into a single message
+type lineCollapser struct {
+	w             io.Writer
+	width         int
+	isFirst       bool
+	lastData      []byte
+	repeatedCount int
+}
+
+func newLineCollapser(w io.Writer, width int) *lineCollapser {
+	return &lineCollapser{
+		w:        w,
+		width:    width,
+		isFirst:  true,
+		lastData: make([]byte, width),
+	}
+}
+
+// print prints formatted line s unless data is the same as on the previous line.
+// Collapsing can be prevented with canCollapse, for example for highlighted lines.
+func (c *lineCollapser) print(data []byte, s string, canCollapse bool) {
+	if canCollapse && !c.isFirst && bytes.Equal(data, c.lastData) {
+		c.repeatedCount++
+	} else {
+		if c.repeatedCount > 0 {
+			_, _ = fmt.Fprintln(c.w, repeatedLinesMessage(1+c.repeatedCount, c.width))
+		}
+
+		c.repeatedCount = 0
+
+		// Print formatted line
+		// <optional offset formatter #1><split><format 1><split><format N...><optional split><optional offset formatter #2>
+		_, _ = fmt.Fprintln(c.w, s)
+	}
+
+	c.lastData = data
+	c.isFirst = false
+}
+
+// flush prints message of repeated lines which haven't been printed yet
+func (c *lineCollapser) flush() {
+	if c.repeatedCount > 0 {
+		_, _ = fmt.Fprintln(c.w, repeatedLinesMessage(c.repeatedCount, c.width))
+	}
+
+	c.repeatedCount = 0
+}
+
+func main() {
+	p := getParams()
+
+	switch p.mode {
+	case modeDiff:
+		runDiff(p)
+		return
+	case modeCompare:
+		runCompare(p)
+		return
+	case modeFind:
+		runFind(p)
+		return
+	case modeSearch:
+		runSearch(p)
+		return
+	case modeStrings:
+		runStrings(p)
+		return
+	case modeReverse:
+		runReverse(p)
+		return
+	case modeCompat:
+		runCompat(p)
+		return
+	case modeExport:
+		runExport(p)
+		return
+	case modeImage:
+		runImage(p)
+		return
+	case modeOverview:
+		runOverview(p)
+		return
+	case modeStats:
+		runStats(p)
+		return
+	case modeInspect:
+		runInspect(p)
+		return
+	case modeRegions:
+		runRegions(p)
+		return
+	case modeInteractive:
+		runInteractive(p)
+		return
+	}
+
+	switch p.outputFormat {
+	case output.FormatJSON, output.FormatNDJSON:
+		runJSON(p)
+		return
+	case output.FormatHTML:
+		runHTML(p)
+		return
+	case output.FormatSVG:
+		runSVG(p)
+		return
+	}
+
+	fGroup := p.fg
+	source := p.inputs[0].source
+	usingLimit := p.limit > 0
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+
+	r := newReader(p, p.inputs[0])
+
+	collapser := newLineCollapser(os.Stdout, fGroup.Width)
 
 	// Dump hex
 	for {
@@ -309,29 +1307,12 @@ func main() {
 			os.Exit(1)
 		}
 
-		data := r.GetData()
-
-		if !isFirst && bytes.Equal(data, lastData) {
-			repeatedCount++
-		} else {
-			if repeatedCount > 0 {
-				_, _ = fmt.Println("\t" + fmt.Sprintf(`-- last line repeated %[1]d times (%[2]d bytes (0x%04[2]x))`, 1+repeatedCount, (1+repeatedCount)*fGroup.Width))
-			}
-
-			repeatedCount = 0
-
-			// Print formatted line
-			// <optional offset formatter #1><split><format 1><split><format N...><optional split><optional offset formatter #2>
-			_, _ = fmt.Println(s)
-		}
+		collapser.print(r.GetData(), s, true)
 
-		if usingLimit && r.GetReadBytes() >= limit {
+		if usingLimit && r.GetReadBytes() >= p.limit {
 			// Limit is set and found
 			break
 		}
-
-		lastData = data
-		isFirst = false
 	}
 
 	err := source.Close()
@@ -340,9 +1321,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if repeatedCount > 0 {
-		_, _ = fmt.Println("\t" + fmt.Sprintf(`-- last line repeated %[1]d times (%[2]d bytes (0x%04[2]x))`, repeatedCount, repeatedCount*fGroup.Width))
-	}
+	collapser.flush()
 
 	_, _ = fmt.Println()
 	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, r.GetReadBytes()))
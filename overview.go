@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/overview"
+)
+
+// Rows targeted when chunk size is selected automatically
+const overviewRows = 32
+
+// Color group of each chunk class
+var overviewClassColorGroups = map[overview.Class]string{
+	overview.ClassNull:   `NullEOF`,
+	overview.ClassText:   `Printable`,
+	overview.ClassRandom: `UpperByte`,
+	overview.ClassMixed:  `Default`,
+}
+
+// runOverview prints a map of the input where every character summarizes a chunk of bytes
+func runOverview(p params) {
+	in := p.inputs[0]
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+	analyzer := overview.New(getGroupBytes(p.byteGroups, []string{`NullEOF`}), getPrintableBytes(p.byteGroups))
+
+	offset := getInputOffset(in)
+
+	chunkSize := p.overviewChunk
+	if chunkSize == 0 {
+		chunkSize = overview.DefaultChunkSize
+
+		if in.filesize != -1 {
+			// Fit the whole range on one screen
+			size := uint64(in.filesize) - offset
+			if p.limit > 0 && p.limit < size {
+				size = p.limit
+			}
+
+			rowSize := uint64(overview.DefaultChunkPerRow * overviewRows)
+			chunkSize = (size + rowSize - 1) / rowSize
+			if chunkSize == 0 {
+				chunkSize = 1
+			}
+		}
+	}
+
+	var src io.Reader = in.source
+	if p.limit > 0 {
+		src = io.LimitReader(src, int64(p.limit))
+	}
+
+	splitter := p.colorGroupings[`Splitter`] + p.fg.Splitter
+	buf := make([]byte, chunkSize)
+	sb := strings.Builder{}
+	var total uint64
+	isEOF := false
+
+	for !isEOF {
+		sb.Reset()
+		sb.WriteString(p.colorGroupings[`Offset`])
+		sb.WriteString(offsetFormatter.Print(offset))
+		sb.WriteString(splitter)
+
+		chunks := 0
+		lastGroup := ``
+
+		for ; chunks < overview.DefaultChunkPerRow; chunks++ {
+			n, err := io.ReadFull(src, buf)
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+					_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+					os.Exit(1)
+				}
+
+				isEOF = true
+			}
+
+			if n == 0 {
+				break
+			}
+
+			c := analyzer.Analyze(offset, buf[0:n])
+			offset += uint64(n)
+			total += uint64(n)
+
+			group := overviewClassColorGroups[c.Classify()]
+			if group != lastGroup {
+				sb.WriteString(p.colorGroupings[group])
+				lastGroup = group
+			}
+
+			sb.WriteRune(c.Glyph())
+
+			if isEOF {
+				chunks++
+				break
+			}
+		}
+
+		if chunks == 0 {
+			break
+		}
+
+		sb.WriteString(color.Clear)
+		_, _ = fmt.Println(sb.String())
+	}
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Printf("Each character is %d bytes, count of dots is entropy in bits\n", chunkSize)
+
+	sb.Reset()
+	legend := []struct {
+		class overview.Class
+		name  string
+	}{
+		{overview.ClassNull, `null/EOF`},
+		{overview.ClassText, `text`},
+		{overview.ClassRandom, `compressed/encrypted`},
+		{overview.ClassMixed, `other`},
+	}
+
+	for _, l := range legend {
+		sb.WriteString(p.colorGroupings[overviewClassColorGroups[l.class]])
+		sb.WriteString(`⣿ `)
+		sb.WriteString(color.Clear)
+		sb.WriteString(l.name + `  `)
+	}
+
+	_, _ = fmt.Println(strings.TrimSpace(sb.String()))
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, total))
+}
@@ -13,24 +13,26 @@ const (
 	esc             = "\033["
 	Clear           = esc + "0m"
 	SetForeground   = esc + "38;5;"
+	SetBackground   = esc + "48;5;"
 	SetUnderlineOn  = esc + "4m"
 	SetUnderlineOff = esc + "24m"
+	SetReverseOn    = esc + "7m"
+	SetReverseOff   = esc + "27m"
 )
 
-func GetColorGroupColorDefaults(src io.Reader, required []string) (groupcolors map[string]string, err error) {
-	reqFound := make(map[string]bool)
-
-	for _, name := range required {
-		reqFound[name] = false
-	}
-
-	groupcolors = make(map[string]string)
+var (
+	configLine  = regexp.MustCompile(`^([^=]+)=(.+)$`)
+	byteMatcher = regexp.MustCompile(`(\d+)`)
+)
 
-	m, err := regexp.Compile(`^([^=]+)=([^\s]+)`)
-	if err != nil {
-		return nil, err
-	}
+// configEntry is one "name=value" line of a config
+type configEntry struct {
+	name  string
+	value string
+}
 
+// readConfig returns "name=value" lines of a config. Comment lines starting with ';' and other lines are skipped.
+func readConfig(src io.Reader) (entries []configEntry, err error) {
 	scanner := bufio.NewScanner(src)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -38,20 +40,43 @@ func GetColorGroupColorDefaults(src io.Reader, required []string) (groupcolors m
 			// Comment line, skip
 			continue
 		}
-		found := m.FindStringSubmatch(line)
+
+		found := configLine.FindStringSubmatch(line)
 		if found == nil {
 			continue
 		}
 
-		groupName, param := found[1], found[2]
+		entries = append(entries, configEntry{
+			name:  found[1],
+			value: strings.TrimSpace(found[2]),
+		})
+	}
 
-		switch groupName {
+	return entries, scanner.Err()
+}
+
+func GetColorGroupColorDefaults(src io.Reader, required []string) (groupcolors map[string]string, err error) {
+	reqFound := make(map[string]bool)
+
+	for _, name := range required {
+		reqFound[name] = false
+	}
+
+	groupcolors = make(map[string]string)
+
+	entries, err := readConfig(src)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		switch e.name {
 		case `LineEven`, `LineOdd`: // Background
-			reqFound[groupName] = true
-			groupcolors[groupName] = esc + param + "m"
+			reqFound[e.name] = true
+			groupcolors[e.name] = esc + e.value + "m"
 		default:
-			reqFound[groupName] = true
-			groupcolors[groupName] = SetForeground + param + "m"
+			reqFound[e.name] = true
+			groupcolors[e.name] = SetForeground + e.value + "m"
 		}
 	}
 
@@ -64,52 +89,47 @@ func GetColorGroupColorDefaults(src io.Reader, required []string) (groupcolors m
 	return groupcolors, nil
 }
 
+// GetColors returns color for each byte 0-255 from byte color group config and group colors.
+// Bytes not listed in any group get the color of group "Default".
 func GetColors(src io.Reader, groups map[string]string) (byteColors [256]string, err error) {
-	for i := 0; i < 256; i++ {
-		// Set default color for each byte
-		byteColors[i] = groups[`Default`]
-	}
-
-	colorMatcher, err := regexp.Compile(`(\d+)`)
+	byteGroups, err := GetByteGroups(src)
 	if err != nil {
-		panic(err)
+		return byteColors, err
 	}
 
-	m, err := regexp.Compile(`^([^=]+)=([^\r\n]+)`)
-	if err != nil {
-		panic(err)
+	for i, groupName := range byteGroups {
+		byteColors[i] = groups[groupName]
 	}
 
-	scanner := bufio.NewScanner(src)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, ";") {
-			// Comment line, skip
-			continue
-		}
-
-		found := m.FindStringSubmatch(line)
-		if found == nil {
-			continue
-		}
+	return byteColors, nil
+}
 
-		groupName, params := found[1], found[2]
+// GetByteGroups returns group name for each byte 0-255 from byte color group config.
+// Bytes not listed in any group belong to group "Default".
+func GetByteGroups(src io.Reader) (byteGroups [256]string, err error) {
+	for i := 0; i < 256; i++ {
+		byteGroups[i] = `Default`
+	}
 
-		byteList := colorMatcher.FindAllString(params, 1024)
-		if byteList == nil {
-			continue
-		}
+	entries, err := readConfig(src)
+	if err != nil {
+		return byteGroups, err
+	}
 
-		for _, c := range byteList {
+	for _, e := range entries {
+		for _, c := range byteMatcher.FindAllString(e.value, 1024) {
 			n, err := strconv.Atoi(c)
 			if err != nil {
-				return byteColors, err
+				return byteGroups, err
 			}
 
-			byteColors[n] = groups[groupName]
-		}
+			if n < 0 || n > 255 {
+				return byteGroups, fmt.Errorf(`invalid byte %v in group %v`, n, e.name)
+			}
 
+			byteGroups[n] = e.name
+		}
 	}
 
-	return byteColors, nil
+	return byteGroups, nil
 }
@@ -0,0 +1,59 @@
+package color
+
+import (
+	"strings"
+	"testing"
+)
+
+const testByteGroups = `
+; comment=1 2 3
+NullEOF=0 255
+Number=48 49
+`
+
+func TestGetByteGroups(t *testing.T) {
+	groups, err := GetByteGroups(strings.NewReader(testByteGroups))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[int]string{0: `NullEOF`, 255: `NullEOF`, 48: `Number`, 49: `Number`, 1: `Default`, 50: `Default`}
+	for b, name := range expected {
+		if groups[b] != name {
+			t.Errorf(`byte %d: expected %v, got %v`, b, name, groups[b])
+		}
+	}
+
+	if _, err := GetByteGroups(strings.NewReader(`Big=256`)); err == nil {
+		t.Error(`expected error for byte > 255`)
+	}
+}
+
+func TestGetColors(t *testing.T) {
+	groupColors, err := GetColorGroupColorDefaults(strings.NewReader("LineEven=48;5;235\nDefault=255\nNullEOF=124\nNumber=67\n"), []string{`Default`, `LineEven`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if groupColors[`LineEven`] != esc+`48;5;235m` || groupColors[`Number`] != SetForeground+`67m` {
+		t.Errorf(`unexpected group colors %q`, groupColors)
+	}
+
+	colors, err := GetColors(strings.NewReader(testByteGroups), groupColors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if colors[0] != groupColors[`NullEOF`] || colors[48] != groupColors[`Number`] || colors[1] != groupColors[`Default`] {
+		t.Errorf(`unexpected byte colors %q %q %q`, colors[0], colors[48], colors[1])
+	}
+
+	indexes, err := GetColorGroupIndexes(strings.NewReader("LineEven=48;5;235\nNumber=67\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if indexes[`LineEven`] != 235 || indexes[`Number`] != 67 {
+		t.Errorf(`unexpected indexes %v`, indexes)
+	}
+}
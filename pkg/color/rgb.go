@@ -0,0 +1,127 @@
+package color
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type RGB struct {
+	R, G, B uint8
+}
+
+// Hex returns color in CSS form (#rrggbb)
+func (c RGB) Hex() string {
+	return fmt.Sprintf(`#%02x%02x%02x`, c.R, c.G, c.B)
+}
+
+// xterm's default colors for the first 16 palette entries
+var ansiSystemColors = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// Levels of one channel in the 6x6x6 color cube
+var ansiCubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// ANSI256ToRGB converts ANSI 256 color palette index to RGB
+func ANSI256ToRGB(n uint8) RGB {
+	switch {
+	case n < 16:
+		return ansiSystemColors[n]
+	case n < 232:
+		n -= 16
+		return RGB{ansiCubeLevels[n/36], ansiCubeLevels[(n/6)%6], ansiCubeLevels[n%6]}
+	default:
+		gray := 8 + 10*(n-232)
+		return RGB{gray, gray, gray}
+	}
+}
+
+// GetColorGroupIndexes returns ANSI 256 color palette index of each group from color group config.
+// For example "LineEven=48;5;235" gives 235.
+func GetColorGroupIndexes(src io.Reader) (groupIndexes map[string]uint8, err error) {
+	groupIndexes = make(map[string]uint8)
+
+	entries, err := readConfig(src)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		params := strings.Split(e.value, `;`)
+
+		// Last parameter is the palette index
+		n, err := strconv.ParseUint(params[len(params)-1], 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid color %q in group %v: %w`, e.value, e.name, err)
+		}
+
+		groupIndexes[e.name] = uint8(n)
+	}
+
+	return groupIndexes, nil
+}
+
+// GetRGBPalette returns RGB color for each byte 0-255 from byte groups and group color indexes
+func GetRGBPalette(byteGroups [256]string, groupIndexes map[string]uint8) (palette [256]RGB) {
+	for i, group := range byteGroups {
+		idx, ok := groupIndexes[group]
+		if !ok {
+			idx = groupIndexes[`Default`]
+		}
+
+		palette[i] = ANSI256ToRGB(idx)
+	}
+
+	return palette
+}
+
+// RGBToANSI256 returns the nearest ANSI 256 color palette index from the 6x6x6 color cube or the grayscale ramp
+func RGBToANSI256(c RGB) uint8 {
+	// Nearest level in the color cube
+	level := func(v uint8) uint8 {
+		if v < 48 {
+			return 0
+		}
+
+		if v < 115 {
+			return 1
+		}
+
+		return (v - 35) / 40
+	}
+
+	r, g, b := level(c.R), level(c.G), level(c.B)
+	cubeIdx := 16 + 36*r + 6*g + b
+
+	// Nearest gray
+	avg := (int(c.R) + int(c.G) + int(c.B)) / 3
+	grayStep := 23
+	if avg < 238 {
+		grayStep = (avg - 3) / 10
+		if grayStep < 0 {
+			grayStep = 0
+		}
+	}
+
+	grayIdx := uint8(232 + grayStep)
+
+	if distance(c, ANSI256ToRGB(grayIdx)) < distance(c, ANSI256ToRGB(cubeIdx)) {
+		return grayIdx
+	}
+
+	return cubeIdx
+}
+
+// distance returns squared distance of two colors
+func distance(a, b RGB) int {
+	dr := int(a.R) - int(b.R)
+	dg := int(a.G) - int(b.G)
+	db := int(a.B) - int(b.B)
+
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,172 @@
+package compat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/hex"
+)
+
+type Profile uint8
+
+const (
+	ProfileXxd     Profile = iota // xxd
+	ProfileHexdump                // hexdump -C
+	ProfileOd                     // od -Ax -tx1
+)
+
+// Get enum from string
+var profileStringToEnumMap = map[string]Profile{
+	`xxd`:     ProfileXxd,
+	`hexdump`: ProfileHexdump,
+	`od`:      ProfileOd,
+}
+
+// GetProfile returns profile from string
+func GetProfile(s string) (Profile, error) {
+	p, ok := profileStringToEnumMap[s]
+	if !ok {
+		return p, fmt.Errorf(`invalid: %q, valid: %v`, s, strings.Join(GetProfileList(), `, `))
+	}
+
+	return p, nil
+}
+
+// GetProfileList lists profiles as strings for usage information
+func GetProfileList() (profiles []string) {
+	for s := range profileStringToEnumMap {
+		profiles = append(profiles, s)
+	}
+
+	sort.Strings(profiles)
+	return profiles
+}
+
+// Printer prints lines in the exact layout of another tool
+type Printer struct {
+	profile Profile
+	width   int
+	palette [256]string // Optional colors, empty strings disable
+	clear   string      // ANSI clear code if colors are used
+	sb      strings.Builder
+}
+
+// New creates printer for given profile. Colors are used if palette contains colors and clear is not empty.
+func New(profile Profile, width int, palette [256]string, clear string) *Printer {
+	return &Printer{
+		profile: profile,
+		width:   width,
+		palette: palette,
+		clear:   clear,
+	}
+}
+
+// Squeezes tells if repeated lines are replaced with a single '*' line
+func (p *Printer) Squeezes() bool {
+	return p.profile != ProfileXxd
+}
+
+// Squeezed returns line which is printed instead of repeated lines
+func (p *Printer) Squeezed() string {
+	return `*`
+}
+
+// End returns the last line after all data has been printed, empty if the tool doesn't print one.
+// offset is the offset after the last byte and read is the count of bytes printed.
+func (p *Printer) End(offset uint64, read uint64) (string, bool) {
+	switch p.profile {
+	case ProfileHexdump:
+		if read == 0 {
+			// hexdump prints nothing for empty input
+			return ``, false
+		}
+
+		return fmt.Sprintf(`%08x`, offset), true
+	case ProfileOd:
+		return fmt.Sprintf(`%06x`, offset), true
+	default:
+		return ``, false
+	}
+}
+
+// Line formats one line of data
+func (p *Printer) Line(offset uint64, data []byte) string {
+	p.sb.Reset()
+
+	switch p.profile {
+	case ProfileXxd:
+		// 00000000: 7f45 4c46 0201 0100 0000 0000 0000 0000  .ELF............
+		p.sb.WriteString(fmt.Sprintf(`%08x: `, offset))
+
+		for i := 0; i < p.width; i++ {
+			p.writeHex(data, i)
+
+			if i%2 == 1 || i == p.width-1 {
+				p.sb.WriteString(` `)
+			}
+		}
+
+		p.sb.WriteString(` `)
+		p.writeASCII(data)
+	case ProfileHexdump:
+		// 00000000  7f 45 4c 46 02 01 01 00  00 00 00 00 00 00 00 00  |.ELF............|
+		p.sb.WriteString(fmt.Sprintf(`%08x  `, offset))
+
+		for i := 0; i < p.width; i++ {
+			if i != 0 && i%8 == 0 {
+				p.sb.WriteString(` `)
+			}
+
+			p.writeHex(data, i)
+			p.sb.WriteString(` `)
+		}
+
+		p.sb.WriteString(` |`)
+		p.writeASCII(data)
+		p.sb.WriteString(`|`)
+	case ProfileOd:
+		// 000000 7f 45 4c 46 02 01 01 00 00 00 00 00 00 00 00 00
+		p.sb.WriteString(fmt.Sprintf(`%06x`, offset))
+
+		for i := range data {
+			p.sb.WriteString(` `)
+			p.writeHex(data, i)
+		}
+	}
+
+	return p.sb.String()
+}
+
+// writeHex writes byte at index i as hex or spaces if there's no data
+func (p *Printer) writeHex(data []byte, i int) {
+	if i >= len(data) {
+		p.sb.WriteString(`  `)
+		return
+	}
+
+	p.writeColored(data[i], hex.HexByteToString[data[i]])
+}
+
+// writeASCII writes printable characters and '.' for others
+func (p *Printer) writeASCII(data []byte) {
+	for _, b := range data {
+		c := `.`
+		if b >= 0x20 && b <= 0x7e {
+			c = string(rune(b))
+		}
+
+		p.writeColored(b, c)
+	}
+}
+
+func (p *Printer) writeColored(b byte, s string) {
+	if p.clear == `` {
+		p.sb.WriteString(s)
+		return
+	}
+
+	p.sb.WriteString(p.palette[b])
+	p.sb.WriteString(s)
+	p.sb.WriteString(p.clear)
+}
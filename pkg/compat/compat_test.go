@@ -0,0 +1,53 @@
+package compat
+
+import (
+	"testing"
+)
+
+func TestEnd(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		offset   uint64
+		read     uint64
+		expected string
+		ok       bool
+	}{
+		{`hexdump`, ProfileHexdump, 0x20, 0x20, `00000020`, true},
+		{`hexdump empty`, ProfileHexdump, 0, 0, ``, false},
+		{`od`, ProfileOd, 0x20, 0x20, `000020`, true},
+		{`od empty`, ProfileOd, 0, 0, `000000`, true},
+		{`xxd`, ProfileXxd, 0x20, 0x20, ``, false},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.profile, 16, [256]string{}, ``)
+
+		got, ok := p.End(tt.offset, tt.read)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf(`%s: expected %q %v, got %q %v`, tt.name, tt.expected, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestLine(t *testing.T) {
+	data := []byte("ABCDEFGHIJKLMNOPQ")
+
+	tests := []struct {
+		profile  Profile
+		expected string
+	}{
+		{ProfileXxd, `00000010: 4142 4344                                ABCD`},
+		{ProfileHexdump, `00000010  41 42 43 44                                       |ABCD|`},
+		{ProfileOd, `000010 41 42 43 44`},
+	}
+
+	for _, tt := range tests {
+		p := New(tt.profile, 16, [256]string{}, ``)
+
+		got := p.Line(0x10, data[0:4])
+		if got != tt.expected {
+			t.Errorf(`expected %q, got %q`, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package decode
+
+import (
+	"math"
+)
+
+// FloatFormat is a binary floating point format
+type FloatFormat uint8
+
+const (
+	Float16  FloatFormat = iota // IEEE 754 half precision
+	BFloat16                    // Brain floating point, upper half of float32
+	Float32                     // IEEE 754 single precision
+	Float64                     // IEEE 754 double precision
+)
+
+// FloatClass tells what kind of value a floating point number is
+type FloatClass uint8
+
+const (
+	FloatNormal   FloatClass = iota // Normal number
+	FloatZero                       // Positive or negative zero
+	FloatDenormal                   // Subnormal number, very close to zero with reduced precision
+	FloatInf                        // Positive or negative infinity
+	FloatNaN                        // Not a number
+)
+
+// Size returns size of the format in bytes
+func (f FloatFormat) Size() int {
+	switch f {
+	case Float16, BFloat16:
+		return 2
+	case Float32:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// Precision returns count of significant decimal digits which the format can represent
+func (f FloatFormat) Precision() int {
+	switch f {
+	case Float16:
+		return 4
+	case BFloat16:
+		return 3
+	case Float32:
+		return 7
+	default:
+		return 16
+	}
+}
+
+// Decode converts bits (lowest Size() bytes are used) to value and classifies it
+func (f FloatFormat) Decode(bits uint64) (value float64, class FloatClass) {
+	switch f {
+	case Float16:
+		return DecodeFloat16(uint16(bits))
+	case BFloat16:
+		// bfloat16 is float32 without the lowest 16 bits of the mantissa
+		return decodeIEEE(bits<<16, 8, 23, float64(math.Float32frombits(uint32(bits)<<16)))
+	case Float32:
+		return decodeIEEE(bits, 8, 23, float64(math.Float32frombits(uint32(bits))))
+	default:
+		return decodeIEEE(bits, 11, 52, math.Float64frombits(bits))
+	}
+}
+
+// DecodeFloat16 converts IEEE 754 half precision bits to value
+func DecodeFloat16(bits uint16) (value float64, class FloatClass) {
+	sign := 1.0
+	if bits&0x8000 != 0 {
+		sign = -1.0
+	}
+
+	exponent := int(bits>>10) & 0x1F
+	mantissa := float64(bits & 0x3FF)
+
+	switch exponent {
+	case 0:
+		value = sign * math.Ldexp(mantissa, -24)
+	case 0x1F:
+		value = sign * math.Inf(1)
+		if mantissa != 0 {
+			value = math.NaN()
+		}
+	default:
+		value = sign * math.Ldexp(1+mantissa/1024, exponent-15)
+	}
+
+	return decodeIEEE(uint64(bits), 5, 10, value)
+}
+
+// decodeIEEE classifies IEEE 754 style number from its exponent and mantissa bits
+func decodeIEEE(bits uint64, exponentBits uint, mantissaBits uint, value float64) (float64, FloatClass) {
+	exponent := (bits >> mantissaBits) & (1<<exponentBits - 1)
+	mantissa := bits & (1<<mantissaBits - 1)
+
+	switch {
+	case exponent == 1<<exponentBits-1 && mantissa != 0:
+		return value, FloatNaN
+	case exponent == 1<<exponentBits-1:
+		return value, FloatInf
+	case exponent == 0 && mantissa != 0:
+		return value, FloatDenormal
+	case exponent == 0:
+		return value, FloatZero
+	}
+
+	return value, FloatNormal
+}
@@ -0,0 +1,54 @@
+package decode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloatDecode(t *testing.T) {
+	tests := []struct {
+		format FloatFormat
+		bits   uint64
+		value  float64
+		class  FloatClass
+	}{
+		{Float16, 0x3C00, 1, FloatNormal},
+		{Float16, 0xC000, -2, FloatNormal},
+		{Float16, 0x7BFF, 65504, FloatNormal},
+		{Float16, 0x0001, math.Ldexp(1, -24), FloatDenormal},
+		{Float16, 0x8000, 0, FloatZero},
+		{Float16, 0x7C00, math.Inf(1), FloatInf},
+		{Float16, 0xFC00, math.Inf(-1), FloatInf},
+		{Float16, 0x7E00, math.NaN(), FloatNaN},
+		{BFloat16, 0x3F80, 1, FloatNormal},
+		{BFloat16, 0x4049, 3.140625, FloatNormal},
+		{BFloat16, 0x0001, float64(math.Float32frombits(0x00010000)), FloatDenormal},
+		{BFloat16, 0xFF80, math.Inf(-1), FloatInf},
+		{Float32, 0x40490FDB, float64(float32(math.Pi)), FloatNormal},
+		{Float32, 0x00000001, float64(math.SmallestNonzeroFloat32), FloatDenormal},
+		{Float32, 0x7FC00000, math.NaN(), FloatNaN},
+		{Float64, math.Float64bits(-1.5), -1.5, FloatNormal},
+		{Float64, 1, math.SmallestNonzeroFloat64, FloatDenormal},
+		{Float64, math.Float64bits(math.Inf(1)), math.Inf(1), FloatInf},
+	}
+
+	for _, tc := range tests {
+		value, class := tc.format.Decode(tc.bits)
+
+		if class != tc.class {
+			t.Errorf(`%v %#x: expected class %v, got %v`, tc.format, tc.bits, tc.class, class)
+		}
+
+		if math.IsNaN(tc.value) {
+			if !math.IsNaN(value) {
+				t.Errorf(`%v %#x: expected NaN, got %v`, tc.format, tc.bits, value)
+			}
+
+			continue
+		}
+
+		if value != tc.value {
+			t.Errorf(`%v %#x: expected %v, got %v`, tc.format, tc.bits, tc.value, value)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package decode
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UTF16Rune decodes one UTF-16 character from the beginning of b. Surrogate pairs are combined.
+// Size is the count of bytes used, 0 if b is too short. Invalid sequence returns utf8.RuneError with size 2.
+func UTF16Rune(b []byte, order binary.ByteOrder) (r rune, size int) {
+	if len(b) < 2 {
+		return utf8.RuneError, 0
+	}
+
+	r1 := rune(order.Uint16(b))
+
+	if !utf16.IsSurrogate(r1) {
+		return r1, 2
+	}
+
+	if len(b) < 4 {
+		return utf8.RuneError, 2
+	}
+
+	r = utf16.DecodeRune(r1, rune(order.Uint16(b[2:])))
+	if r == utf8.RuneError {
+		return r, 2
+	}
+
+	return r, 4
+}
@@ -0,0 +1,31 @@
+package decode
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestUTF16Rune(t *testing.T) {
+	tests := []struct {
+		data  []byte
+		order binary.ByteOrder
+		r     rune
+		size  int
+	}{
+		{[]byte{0x41, 0x00}, binary.LittleEndian, 'A', 2},
+		{[]byte{0x00, 0x41}, binary.BigEndian, 'A', 2},
+		{[]byte{0xAC, 0x20, 0xFF}, binary.LittleEndian, '€', 2},
+		{[]byte{0x3D, 0xD8, 0x00, 0xDE}, binary.LittleEndian, '😀', 4},
+		{[]byte{0xD8, 0x3D, 0xDE, 0x00}, binary.BigEndian, '😀', 4},
+		{[]byte{0x3D, 0xD8, 0x41, 0x00}, binary.LittleEndian, utf8.RuneError, 2},
+		{[]byte{0x41}, binary.LittleEndian, utf8.RuneError, 0},
+	}
+
+	for _, tc := range tests {
+		r, size := UTF16Rune(tc.data, tc.order)
+		if r != tc.r || size != tc.size {
+			t.Errorf(`% x: expected %q (%d bytes), got %q (%d bytes)`, tc.data, tc.r, tc.size, r, size)
+		}
+	}
+}
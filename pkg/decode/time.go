@@ -0,0 +1,50 @@
+package decode
+
+import (
+	"time"
+)
+
+// Start times of different timestamp formats
+var (
+	UnixEpoch     = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	FileTimeEpoch = time.Date(1601, time.January, 1, 0, 0, 0, 0, time.UTC) // Windows FILETIME
+	HFSEpoch      = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC) // Mac HFS and HFS+
+	GPSEpoch      = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC) // GPS time (without leap seconds)
+)
+
+// FileTime converts Windows FILETIME (100 ns intervals since 1601-01-01) to time
+func FileTime(v uint64) time.Time {
+	return time.Unix(int64(v/10000000)+FileTimeEpoch.Unix(), int64(v%10000000)*100).UTC()
+}
+
+// SinceEpoch converts seconds since given epoch to time
+func SinceEpoch(epoch time.Time, seconds int64) time.Time {
+	return time.Unix(epoch.Unix()+seconds, 0).UTC()
+}
+
+// DOSDateTime converts MS-DOS packed date (high 16 bits) and time (low 16 bits) to time.
+// ok is false if any of the fields is out of range.
+// FAT and ZIP store time before date, so they are read as a little endian 32-bit value.
+func DOSDateTime(v uint32) (t time.Time, ok bool) {
+	date := v >> 16
+
+	year := int(date>>9) + 1980
+	month := int(date>>5) & 0x0F
+	day := int(date) & 0x1F
+	hour := int(v>>11) & 0x1F
+	minute := int(v>>5) & 0x3F
+	second := int(v&0x1F) * 2
+
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return t, false
+	}
+
+	t = time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC)
+
+	if t.Day() != day {
+		// Day doesn't exist in the month, such as 31st of April
+		return t, false
+	}
+
+	return t, true
+}
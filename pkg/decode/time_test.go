@@ -0,0 +1,57 @@
+package decode
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFileTime(t *testing.T) {
+	tests := map[uint64]time.Time{
+		0:                  FileTimeEpoch,
+		116444736000000000: UnixEpoch,
+		132000000001234567: time.Date(2019, time.April, 17, 18, 40, 0, 123456700, time.UTC),
+	}
+
+	for v, expected := range tests {
+		if got := FileTime(v); !got.Equal(expected) {
+			t.Errorf(`%d: expected %v, got %v`, v, expected, got)
+		}
+	}
+}
+
+func TestSinceEpoch(t *testing.T) {
+	if got := SinceEpoch(HFSEpoch, 2082844800); !got.Equal(UnixEpoch) {
+		t.Errorf(`HFS: expected %v, got %v`, UnixEpoch, got)
+	}
+
+	expected := time.Date(1980, time.January, 13, 0, 0, 0, 0, time.UTC)
+	if got := SinceEpoch(GPSEpoch, 7*24*60*60); !got.Equal(expected) {
+		t.Errorf(`GPS: expected %v, got %v`, expected, got)
+	}
+}
+
+func TestDOSDateTime(t *testing.T) {
+	tests := []struct {
+		v        uint32
+		expected time.Time
+		ok       bool
+	}{
+		{0x00210000, time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{0x4E8F6D5E, time.Date(2019, time.April, 15, 13, 42, 60, 0, time.UTC), false},
+		{0x4E8F6D5D, time.Date(2019, time.April, 15, 13, 42, 58, 0, time.UTC), true},
+		{0x00000000, time.Time{}, false},
+		{0x4E9F0000, time.Time{}, false}, // 31st of April
+	}
+
+	for _, tc := range tests {
+		got, ok := DOSDateTime(tc.v)
+		if ok != tc.ok {
+			t.Errorf(`%#08x: expected ok %v, got %v`, tc.v, tc.ok, ok)
+			continue
+		}
+
+		if ok && !got.Equal(tc.expected) {
+			t.Errorf(`%#08x: expected %v, got %v`, tc.v, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package decode
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// UUIDSize is size of UUID and GUID in bytes
+const UUIDSize = 16
+
+// FormatUUID formats 16 bytes as UUID in RFC 4122 byte order (all fields big endian)
+func FormatUUID(b []byte) string {
+	return fmt.Sprintf(`%08x-%04x-%04x-%x-%x`,
+		binary.BigEndian.Uint32(b[0:4]), binary.BigEndian.Uint16(b[4:6]), binary.BigEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
+
+// FormatGUID formats 16 bytes as Microsoft GUID, where the first three fields are little endian
+func FormatGUID(b []byte) string {
+	return fmt.Sprintf(`%08x-%04x-%04x-%x-%x`,
+		binary.LittleEndian.Uint32(b[0:4]), binary.LittleEndian.Uint16(b[4:6]), binary.LittleEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
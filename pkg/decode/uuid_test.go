@@ -0,0 +1,17 @@
+package decode
+
+import (
+	"testing"
+)
+
+func TestUUID(t *testing.T) {
+	b := []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xAA, 0xBB, 0xCC, 0xDD, 0xEE, 0xFF}
+
+	if got, expected := FormatUUID(b), `33221100-5544-7766-8899-aabbccddeeff`; got != expected {
+		t.Errorf(`UUID: expected %v, got %v`, expected, got)
+	}
+
+	if got, expected := FormatGUID(b), `00112233-4455-6677-8899-aabbccddeeff`; got != expected {
+		t.Errorf(`GUID: expected %v, got %v`, expected, got)
+	}
+}
@@ -0,0 +1,59 @@
+package decode
+
+// MaxVarintLen is the maximum length of 64-bit LEB128 encoded value in bytes
+const MaxVarintLen = 10
+
+// ULEB128 decodes unsigned LEB128 value from the beginning of b.
+// Count of bytes read is returned in n, n is 0 if b ends before the value or the value overflows 64 bits.
+func ULEB128(b []byte) (value uint64, n int) {
+	var shift uint
+
+	for i, c := range b {
+		if i == MaxVarintLen || (i == MaxVarintLen-1 && c > 1) {
+			// Overflow
+			return 0, 0
+		}
+
+		value |= uint64(c&0x7F) << shift
+
+		if c&0x80 == 0 {
+			return value, i + 1
+		}
+
+		shift += 7
+	}
+
+	return 0, 0
+}
+
+// SLEB128 decodes signed (two's complement, sign extended) LEB128 value from the beginning of b.
+// Count of bytes read is returned in n, n is 0 if b ends before the value or the value overflows 64 bits.
+func SLEB128(b []byte) (value int64, n int) {
+	var shift uint
+
+	for i, c := range b {
+		if i == MaxVarintLen {
+			// Overflow
+			return 0, 0
+		}
+
+		value |= int64(c&0x7F) << shift
+		shift += 7
+
+		if c&0x80 == 0 {
+			if shift < 64 && c&0x40 != 0 {
+				// Sign extend
+				value |= -1 << shift
+			}
+
+			return value, i + 1
+		}
+	}
+
+	return 0, 0
+}
+
+// ZigZag decodes protobuf style zig-zag encoded signed value
+func ZigZag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,69 @@
+package decode
+
+import (
+	"testing"
+)
+
+func TestLEB128(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		unsigned uint64
+		signed   int64
+		n        int
+	}{
+		{[]byte{0x00}, 0, 0, 1},
+		{[]byte{0x02, 0xFF}, 2, 2, 1},
+		{[]byte{0x7F}, 127, -1, 1},
+		{[]byte{0x80, 0x01}, 128, 128, 2},
+		{[]byte{0xE5, 0x8E, 0x26}, 624485, 624485, 3},
+		{[]byte{0xC0, 0xBB, 0x78}, 1973696, -123456, 3},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01}, 0xFFFFFFFFFFFFFFFF, -1, 10},
+	}
+
+	for _, tc := range tests {
+		u, n := ULEB128(tc.data)
+		if u != tc.unsigned || n != tc.n {
+			t.Errorf(`ULEB128 % x: expected %d (%d bytes), got %d (%d bytes)`, tc.data, tc.unsigned, tc.n, u, n)
+		}
+
+		s, n := SLEB128(tc.data)
+		if s != tc.signed || n != tc.n {
+			t.Errorf(`SLEB128 % x: expected %d (%d bytes), got %d (%d bytes)`, tc.data, tc.signed, tc.n, s, n)
+		}
+	}
+}
+
+func TestLEB128Invalid(t *testing.T) {
+	tests := [][]byte{
+		{},
+		{0x80, 0x80},
+		{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01},
+	}
+
+	for _, data := range tests {
+		if _, n := ULEB128(data); n != 0 {
+			t.Errorf(`ULEB128 % x: expected failure, got %d bytes`, data, n)
+		}
+
+		if _, n := SLEB128(data); n != 0 {
+			t.Errorf(`SLEB128 % x: expected failure, got %d bytes`, data, n)
+		}
+	}
+}
+
+func TestZigZag(t *testing.T) {
+	tests := map[uint64]int64{
+		0:          0,
+		1:          -1,
+		2:          1,
+		3:          -2,
+		4294967294: 2147483647,
+		4294967295: -2147483648,
+	}
+
+	for v, expected := range tests {
+		if got := ZigZag(v); got != expected {
+			t.Errorf(`%d: expected %d, got %d`, v, expected, got)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package diff
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+type HunkType uint8
+
+const (
+	HunkEqual   HunkType = iota // Same bytes in both
+	HunkInsert                  // Bytes exist only in the right side
+	HunkDelete                  // Bytes exist only in the left side
+	HunkReplace                 // Bytes were changed (lengths may differ)
+)
+
+// String returns name of hunk type for machine-readable output
+func (t HunkType) String() string {
+	switch t {
+	case HunkEqual:
+		return `equal`
+	case HunkInsert:
+		return `insert`
+	case HunkDelete:
+		return `delete`
+	case HunkReplace:
+		return `replace`
+	default:
+		return `unknown`
+	}
+}
+
+// Hunk is a range in both inputs. Offsets are relative to the start of compared data.
+type Hunk struct {
+	Type        HunkType
+	LeftOffset  uint64
+	LeftLength  uint64
+	RightOffset uint64
+	RightLength uint64
+}
+
+// Summary returns human readable summary of hunk, for example "+16 bytes inserted"
+func (h Hunk) Summary() string {
+	switch h.Type {
+	case HunkInsert:
+		return fmt.Sprintf(`+%d bytes inserted`, h.RightLength)
+	case HunkDelete:
+		return fmt.Sprintf(`-%d bytes deleted`, h.LeftLength)
+	case HunkReplace:
+		if h.LeftLength == h.RightLength {
+			return fmt.Sprintf(`~%d bytes changed`, h.LeftLength)
+		}
+
+		return fmt.Sprintf(`~%d bytes replaced with %d bytes`, h.LeftLength, h.RightLength)
+	default:
+		return fmt.Sprintf(`%d bytes equal`, h.LeftLength)
+	}
+}
+
+// How many bytes must match after a difference before inputs are considered to be in sync again
+const syncBlockSize = 8
+
+// Search windows for re-synchronization, smaller windows are tried first because most changes are small
+var syncWindows = []int{64, 1024, 16 * 1024, 256 * 1024}
+
+// Align compares a and b and returns hunks which describe how to get from a to b.
+// Insertions and deletions are detected by searching the nearest position where both inputs are in sync again
+// using a rolling block hash. If no sync position is found within the largest window, the window is reported as replaced.
+func Align(a, b []byte) (hunks []Hunk) {
+	i, j := 0, 0
+
+	add := func(t HunkType, alen, blen int) {
+		if alen == 0 && blen == 0 {
+			return
+		}
+
+		hunks = append(hunks, Hunk{
+			Type:        t,
+			LeftOffset:  uint64(i),
+			LeftLength:  uint64(alen),
+			RightOffset: uint64(j),
+			RightLength: uint64(blen),
+		})
+
+		i += alen
+		j += blen
+	}
+
+	for i < len(a) && j < len(b) {
+		// Equal run
+		n := 0
+		for i+n < len(a) && j+n < len(b) && a[i+n] == b[j+n] {
+			n++
+		}
+		add(HunkEqual, n, n)
+
+		if i == len(a) || j == len(b) {
+			break
+		}
+
+		if len(a)-i < syncBlockSize || len(b)-j < syncBlockSize {
+			// Too short tail to sync, but common suffix can still be equal
+			suffix := 0
+			for suffix < len(a)-i && suffix < len(b)-j && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+				suffix++
+			}
+
+			alen, blen := len(a)-i-suffix, len(b)-j-suffix
+			add(changeType(alen, blen), alen, blen)
+			add(HunkEqual, suffix, suffix)
+			break
+		}
+
+		found := false
+		for _, window := range syncWindows {
+			da, db, ok := findSync(a[i:], b[j:], window)
+			if ok {
+				add(changeType(da, db), da, db)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			window := syncWindows[len(syncWindows)-1]
+			alen, blen := minInt(window, len(a)-i), minInt(window, len(b)-j)
+			add(HunkReplace, alen, blen)
+		}
+	}
+
+	// Leftovers
+	add(HunkDelete, len(a)-i, 0)
+	add(HunkInsert, 0, len(b)-j)
+
+	return hunks
+}
+
+// changeType returns type of hunk which changes alen bytes to blen bytes
+func changeType(alen, blen int) HunkType {
+	if alen == 0 {
+		return HunkInsert
+	} else if blen == 0 {
+		return HunkDelete
+	}
+
+	return HunkReplace
+}
+
+// findSync finds the nearest position (da, db) within window where a and b have syncBlockSize equal bytes.
+// Nearest means the smallest da+db, so pure insertions and deletions are preferred over replacements.
+func findSync(a, b []byte, window int) (da int, db int, ok bool) {
+	// Index block positions of b, only the first position of each block is needed
+	positions := make(map[uint64]int)
+	for k := 0; k <= window && k+syncBlockSize <= len(b); k++ {
+		key := binary.LittleEndian.Uint64(b[k : k+syncBlockSize])
+		if _, exists := positions[key]; !exists {
+			positions[key] = k
+		}
+	}
+
+	best := -1
+	for k := 0; k <= window && k+syncBlockSize <= len(a); k++ {
+		if best != -1 && k >= best {
+			// Can't get any nearer
+			break
+		}
+
+		pos, exists := positions[binary.LittleEndian.Uint64(a[k:k+syncBlockSize])]
+		if !exists {
+			continue
+		}
+
+		if best == -1 || k+pos < best {
+			best = k + pos
+			da, db = k, pos
+		}
+	}
+
+	return da, db, best != -1
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testData returns 256 bytes which don't repeat
+func testData() []byte {
+	b := make([]byte, 256)
+	for i := range b {
+		b[i] = byte(i)
+	}
+
+	return b
+}
+
+func TestAlignEqual(t *testing.T) {
+	a := testData()
+
+	got := Align(a, a)
+
+	if len(got) != 1 || got[0].Type != HunkEqual || got[0].LeftLength != 256 {
+		t.Fail()
+	}
+}
+
+func TestAlignInsert(t *testing.T) {
+	a := testData()
+	b := append(append(append([]byte{}, a[:100]...), bytes.Repeat([]byte{0xAA}, 16)...), a[100:]...)
+
+	expected := []Hunk{
+		{Type: HunkEqual, LeftOffset: 0, LeftLength: 100, RightOffset: 0, RightLength: 100},
+		{Type: HunkInsert, LeftOffset: 100, LeftLength: 0, RightOffset: 100, RightLength: 16},
+		{Type: HunkEqual, LeftOffset: 100, LeftLength: 156, RightOffset: 116, RightLength: 156},
+	}
+
+	got := Align(a, b)
+
+	if len(got) != len(expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf(`expected %v, got %v`, expected, got)
+		}
+	}
+}
+
+func TestAlignDelete(t *testing.T) {
+	a := testData()
+	b := append(append([]byte{}, a[:50]...), a[60:]...)
+
+	got := Align(a, b)
+
+	if len(got) != 3 || got[1].Type != HunkDelete || got[1].LeftOffset != 50 || got[1].LeftLength != 10 {
+		t.Fatalf(`unexpected %v`, got)
+	}
+}
+
+func TestAlignReplace(t *testing.T) {
+	a := testData()
+	b := append([]byte{}, a...)
+	b[10] = 0xFF
+	b[11] = 0xFF
+
+	got := Align(a, b)
+
+	if len(got) != 3 || got[1].Type != HunkReplace || got[1].LeftLength != 2 || got[1].RightLength != 2 {
+		t.Fatalf(`unexpected %v`, got)
+	}
+}
+
+func TestAlignTail(t *testing.T) {
+	a := testData()
+	b := append(append([]byte{}, a...), 1, 2, 3)
+
+	got := Align(a, b)
+
+	if len(got) != 2 || got[1].Type != HunkInsert || got[1].RightLength != 3 {
+		t.Fatalf(`unexpected %v`, got)
+	}
+}
+
+func TestPositional(t *testing.T) {
+	p := Positional{}
+	p.Compare([]byte{1, 2, 3, 4}, []byte{1, 0, 0, 4})
+	p.Compare([]byte{5, 6}, []byte{5, 0, 7})
+
+	if p.DifferentBytes != 4 || p.Ranges != 2 {
+		t.Fail()
+	}
+}
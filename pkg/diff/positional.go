@@ -0,0 +1,37 @@
+package diff
+
+// Positional compares two inputs byte by byte at the same positions.
+// Inserted or deleted bytes are not detected, so everything after an insertion is reported as different.
+type Positional struct {
+	DifferentBytes uint64 // How many bytes differ so far
+	Ranges         uint64 // How many continuous ranges of differing bytes there are so far
+	inRange        bool   // Was the last compared byte different? Used for joining ranges between Compare() calls
+}
+
+// Compare compares a and b and returns mask of differing positions.
+// Mask length is the longer length of a and b, positions missing from the shorter one are always different.
+// State is kept between calls so that ranges continuing on the next line are counted only once.
+func (p *Positional) Compare(a, b []byte) (mask []bool) {
+	size := len(a)
+	if len(b) > size {
+		size = len(b)
+	}
+
+	mask = make([]bool, size)
+
+	for i := 0; i < size; i++ {
+		mask[i] = i >= len(a) || i >= len(b) || a[i] != b[i]
+
+		if mask[i] {
+			p.DifferentBytes++
+
+			if !p.inRange {
+				p.Ranges++
+			}
+		}
+
+		p.inRange = mask[i]
+	}
+
+	return mask
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPositionalCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     [][2]string // Left and right line fed to one Compare call
+		expected  []string    // Mask of each line, x = different
+		different uint64
+		ranges    uint64
+	}{
+		{
+			name:     `equal`,
+			lines:    [][2]string{{`abcd`, `abcd`}, {`efgh`, `efgh`}},
+			expected: []string{`....`, `....`},
+		},
+		{
+			name:      `one byte`,
+			lines:     [][2]string{{`abcd`, `abXd`}},
+			expected:  []string{`..x.`},
+			different: 1,
+			ranges:    1,
+		},
+		{
+			name:      `range continues on the next line`,
+			lines:     [][2]string{{`abcd`, `abXX`}, {`efgh`, `XXgX`}},
+			expected:  []string{`..xx`, `xx.x`},
+			different: 5,
+			ranges:    2,
+		},
+		{
+			name:      `right is longer`,
+			lines:     [][2]string{{`abcd`, `abcd`}, {`ef`, `efgh`}},
+			expected:  []string{`....`, `..xx`},
+			different: 2,
+			ranges:    1,
+		},
+		{
+			name:      `left is longer`,
+			lines:     [][2]string{{`abcd`, `abcd`}, {`efgh`, `e`}},
+			expected:  []string{`....`, `.xxx`},
+			different: 3,
+			ranges:    1,
+		},
+		{
+			name:      `file ends mid-line and the other continues`,
+			lines:     [][2]string{{`abcd`, `abc`}, {``, `efgh`}},
+			expected:  []string{`...x`, `xxxx`},
+			different: 5,
+			ranges:    1,
+		},
+		{
+			name:     `both empty`,
+			lines:    [][2]string{{``, ``}},
+			expected: []string{``},
+		},
+	}
+
+	for _, tc := range tests {
+		p := Positional{}
+
+		for i, l := range tc.lines {
+			mask := p.Compare([]byte(l[0]), []byte(l[1]))
+
+			got := ``
+			for _, m := range mask {
+				if m {
+					got += `x`
+				} else {
+					got += `.`
+				}
+			}
+
+			if got != tc.expected[i] {
+				t.Errorf(`%s: line %d: expected %q, got %q`, tc.name, i, tc.expected[i], got)
+			}
+		}
+
+		if fmt.Sprint(p.DifferentBytes, p.Ranges) != fmt.Sprint(tc.different, tc.ranges) {
+			t.Errorf(`%s: expected %d different bytes in %d ranges, got %d in %d`, tc.name, tc.different, tc.ranges, p.DifferentBytes, p.Ranges)
+		}
+	}
+}
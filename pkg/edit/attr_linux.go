@@ -0,0 +1,74 @@
+//go:build linux
+// +build linux
+
+package edit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+)
+
+// linkCount returns count of hard links to the file
+func linkCount(fi os.FileInfo) uint64 {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1
+	}
+
+	return uint64(st.Nlink)
+}
+
+// copyAttributes sets owner, group and extended attributes of file src (with info fi) to dst
+func copyAttributes(fi os.FileInfo, src string, dst *os.File) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return errors.New(`unknown file info`)
+	}
+
+	err := dst.Chown(int(st.Uid), int(st.Gid))
+	if err != nil {
+		return err
+	}
+
+	size, err := syscall.Listxattr(src, nil)
+	if err != nil {
+		if errors.Is(err, syscall.ENOTSUP) {
+			return nil
+		}
+
+		return err
+	}
+
+	if size == 0 {
+		return nil
+	}
+
+	names := make([]byte, size)
+	size, err = syscall.Listxattr(src, names)
+	if err != nil {
+		return err
+	}
+
+	// Names are separated by null bytes
+	for _, name := range bytes.Split(bytes.TrimRight(names[0:size], "\x00"), []byte{0}) {
+		valueSize, err := syscall.Getxattr(src, string(name), nil)
+		if err != nil {
+			return err
+		}
+
+		value := make([]byte, valueSize)
+		valueSize, err = syscall.Getxattr(src, string(name), value)
+		if err != nil {
+			return err
+		}
+
+		err = syscall.Setxattr(dst.Name(), string(name), value[0:valueSize], 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package edit
+
+import (
+	"os"
+)
+
+// linkCount returns count of hard links to the file, always 1 on this operating system
+func linkCount(fi os.FileInfo) uint64 {
+	return 1
+}
+
+// copyAttributes does nothing on this operating system, owner and extended attributes are not kept
+func copyAttributes(fi os.FileInfo, src string, dst *os.File) error {
+	return nil
+}
@@ -0,0 +1,291 @@
+package edit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Check implementation
+var _ io.ReadSeekCloser = &Buffer{}
+
+// piece is a range of bytes from either the original data or the added bytes
+type piece struct {
+	added  bool   // false = original data, true = added bytes
+	offset uint64 // Start offset in the source
+	length uint64
+}
+
+// change is a state of the buffer saved for undo and redo
+type change struct {
+	pieces []piece
+	offset uint64 // Offset where the change was made
+	length uint64 // Count of changed bytes
+}
+
+// Buffer is a piece table over original data. Original data is never modified, changes are stored in a separate
+// append-only buffer and the pieces tell from which one each range is read. Undo and redo swap the piece list.
+type Buffer struct {
+	original io.ReaderAt
+	size     uint64
+	added    []byte  // Bytes written with Overwrite
+	pieces   []piece // Pieces in order, lengths sum up to size
+	undo     []change
+	redo     []change
+	position uint64 // Position for Read and Seek
+}
+
+// New creates buffer of original data with given size
+func New(original io.ReaderAt, size uint64) *Buffer {
+	b := &Buffer{
+		original: original,
+		size:     size,
+	}
+
+	if size > 0 {
+		b.pieces = []piece{{added: false, offset: 0, length: size}}
+	}
+
+	return b
+}
+
+// Size returns size of the data
+func (b *Buffer) Size() uint64 {
+	return b.size
+}
+
+// IsModified tells if there are changes which can be undone
+func (b *Buffer) IsModified() bool {
+	return len(b.undo) > 0
+}
+
+// Overwrite replaces bytes at given offset. Size of the data can't be changed.
+func (b *Buffer) Overwrite(offset uint64, data []byte) error {
+	if offset+uint64(len(data)) > b.size {
+		return fmt.Errorf(`can't write %d bytes at offset %d, size is %d bytes`, len(data), offset, b.size)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	b.undo = append(b.undo, change{pieces: b.pieces, offset: offset, length: uint64(len(data))})
+	b.redo = nil
+
+	newPiece := piece{added: true, offset: uint64(len(b.added)), length: uint64(len(data))}
+	b.added = append(b.added, data...)
+
+	end := offset + newPiece.length
+
+	var pieces []piece
+	var pos uint64
+
+	for _, p := range b.pieces {
+		pieceEnd := pos + p.length
+
+		if pieceEnd <= offset || pos >= end {
+			// Outside of the overwritten range
+			pieces = append(pieces, p)
+		} else {
+			if pos < offset {
+				// Keep the beginning of the piece
+				pieces = append(pieces, piece{added: p.added, offset: p.offset, length: offset - pos})
+			}
+
+			if pos <= offset {
+				pieces = append(pieces, newPiece)
+			}
+
+			if pieceEnd > end {
+				// Keep the end of the piece
+				pieces = append(pieces, piece{added: p.added, offset: p.offset + (end - pos), length: pieceEnd - end})
+			}
+		}
+
+		pos = pieceEnd
+	}
+
+	b.pieces = pieces
+
+	return nil
+}
+
+// Undo reverts the latest change and returns offset where it was made
+func (b *Buffer) Undo() (offset uint64, ok bool) {
+	if len(b.undo) == 0 {
+		return 0, false
+	}
+
+	c := b.undo[len(b.undo)-1]
+	b.undo = b.undo[0 : len(b.undo)-1]
+	b.redo = append(b.redo, change{pieces: b.pieces, offset: c.offset, length: c.length})
+	b.pieces = c.pieces
+
+	return c.offset, true
+}
+
+// Redo applies the latest undone change again and returns offset where it was made
+func (b *Buffer) Redo() (offset uint64, ok bool) {
+	if len(b.redo) == 0 {
+		return 0, false
+	}
+
+	c := b.redo[len(b.redo)-1]
+	b.redo = b.redo[0 : len(b.redo)-1]
+	b.undo = append(b.undo, change{pieces: b.pieces, offset: c.offset, length: c.length})
+	b.pieces = c.pieces
+
+	return c.offset, true
+}
+
+// IsChanged tells if byte at given offset differs from the original data.
+// Byte which has been overwritten with its original value is not changed.
+func (b *Buffer) IsChanged(offset uint64) bool {
+	var pos uint64
+
+	for _, p := range b.pieces {
+		if offset < pos+p.length {
+			if !p.added {
+				return false
+			}
+
+			var orig [1]byte
+			_, err := b.original.ReadAt(orig[:], int64(offset))
+			if err != nil && !errors.Is(err, io.EOF) {
+				// Can't tell, show as changed
+				return true
+			}
+
+			return b.added[p.offset+(offset-pos)] != orig[0]
+		}
+
+		pos += p.length
+	}
+
+	return false
+}
+
+// ReadAt reads bytes from given offset
+func (b *Buffer) ReadAt(data []byte, offset int64) (n int, err error) {
+	if offset < 0 {
+		return 0, errors.New(`negative offset`)
+	}
+
+	var pos uint64
+
+	for _, p := range b.pieces {
+		if n == len(data) {
+			break
+		}
+
+		pieceEnd := pos + p.length
+		from := uint64(offset) + uint64(n)
+
+		if from >= pieceEnd {
+			pos = pieceEnd
+			continue
+		}
+
+		// Read from this piece
+		start := p.offset + (from - pos)
+		count := pieceEnd - from
+		if count > uint64(len(data)-n) {
+			count = uint64(len(data) - n)
+		}
+
+		if p.added {
+			copy(data[n:], b.added[start:start+count])
+		} else {
+			read, err := b.original.ReadAt(data[n:n+int(count)], int64(start))
+			if err != nil && !(errors.Is(err, io.EOF) && uint64(read) == count) {
+				return n + read, err
+			}
+		}
+
+		n += int(count)
+		pos = pieceEnd
+	}
+
+	if n < len(data) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Read reads bytes from current position
+func (b *Buffer) Read(data []byte) (n int, err error) {
+	if b.position >= b.size {
+		return 0, io.EOF
+	}
+
+	n, err = b.ReadAt(data, int64(b.position))
+	b.position += uint64(n)
+
+	if errors.Is(err, io.EOF) && n > 0 {
+		// io.Reader returns EOF on the next call
+		err = nil
+	}
+
+	return n, err
+}
+
+// Seek sets position for the next Read
+func (b *Buffer) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = int64(b.position) + offset
+	case io.SeekEnd:
+		pos = int64(b.size) + offset
+	default:
+		return 0, errors.New(`invalid whence`)
+	}
+
+	if pos < 0 {
+		return 0, errors.New(`negative position`)
+	}
+
+	b.position = uint64(pos)
+
+	return pos, nil
+}
+
+// Close closes the original data if it can be closed
+func (b *Buffer) Close() error {
+	if c, ok := b.original.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// WriteTo writes whole data to w
+func (b *Buffer) WriteTo(w io.Writer) (written int64, err error) {
+	buf := make([]byte, 64*1024)
+
+	for pos := uint64(0); pos < b.size; {
+		n, err := b.ReadAt(buf, int64(pos))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return written, err
+		}
+
+		if n == 0 {
+			// Original data is shorter than expected
+			return written, io.ErrUnexpectedEOF
+		}
+
+		wn, err := w.Write(buf[0:n])
+		written += int64(wn)
+		if err != nil {
+			return written, err
+		}
+
+		pos += uint64(n)
+	}
+
+	return written, nil
+}
@@ -0,0 +1,235 @@
+package edit
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readAll(t *testing.T, b *Buffer) string {
+	t.Helper()
+
+	_, err := b.Seek(0, io.SeekStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := io.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestOverwriteUndoRedo(t *testing.T) {
+	b := New(bytes.NewReader([]byte(`0123456789`)), 10)
+
+	steps := []struct {
+		offset   uint64
+		data     string
+		expected string
+	}{
+		{2, `ab`, `01ab456789`},
+		{3, `XYZ`, `01aXYZ6789`},
+		{0, `-`, `-1aXYZ6789`},
+		{9, `!`, `-1aXYZ678!`},
+		{1, `########`, `-########!`},
+	}
+
+	for _, s := range steps {
+		err := b.Overwrite(s.offset, []byte(s.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := readAll(t, b); got != s.expected {
+			t.Fatalf(`expected %q, got %q`, s.expected, got)
+		}
+	}
+
+	if !b.IsChanged(0) || !b.IsChanged(9) || b.IsChanged(100) {
+		t.Errorf(`wrong changed bytes`)
+	}
+
+	for i := len(steps) - 2; i >= 0; i-- {
+		offset, ok := b.Undo()
+		if !ok || offset != steps[i+1].offset {
+			t.Fatalf(`undo: expected offset %d, got %d (%v)`, steps[i+1].offset, offset, ok)
+		}
+
+		if got := readAll(t, b); got != steps[i].expected {
+			t.Fatalf(`undo: expected %q, got %q`, steps[i].expected, got)
+		}
+	}
+
+	b.Undo()
+
+	if got := readAll(t, b); got != `0123456789` || b.IsModified() || b.IsChanged(2) {
+		t.Fatalf(`expected original data, got %q`, got)
+	}
+
+	b.Redo()
+	b.Redo()
+
+	if got := readAll(t, b); got != steps[1].expected {
+		t.Fatalf(`redo: expected %q, got %q`, steps[1].expected, got)
+	}
+
+	// New change clears redo
+	_ = b.Overwrite(0, []byte(`x`))
+	if _, ok := b.Redo(); ok {
+		t.Errorf(`redo should not be possible after change`)
+	}
+
+	if err := b.Overwrite(9, []byte(`ab`)); err == nil {
+		t.Errorf(`expected error when writing past the end`)
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, `file.bin`)
+
+	err := os.WriteFile(path, []byte(`hello world`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	b := New(f, 11)
+	_ = b.Overwrite(0, []byte(`J`))
+
+	err = Save(b, path, path+`~`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]string{path: `Jello world`, path + `~`: `hello world`} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != expected {
+			t.Errorf(`%v: expected %q, got %q`, name, expected, data)
+		}
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Mode().Perm() != 0640 {
+		t.Errorf(`permissions were not kept: %v`, fi.Mode().Perm())
+	}
+}
+
+func TestIsChangedOriginalValue(t *testing.T) {
+	b := New(bytes.NewReader([]byte(`0123456789`)), 10)
+
+	_ = b.Overwrite(2, []byte(`a3`))
+
+	if !b.IsChanged(2) {
+		t.Errorf(`expected byte 2 to be changed`)
+	}
+
+	// Byte 3 was overwritten with the same value
+	if b.IsChanged(3) || b.IsChanged(4) {
+		t.Errorf(`expected bytes 3 and 4 to be unchanged`)
+	}
+
+	_ = b.Overwrite(2, []byte(`2`))
+
+	if b.IsChanged(2) {
+		t.Errorf(`expected byte 2 written back to its original value to be unchanged`)
+	}
+}
+
+func TestSaveLinks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, `file.bin`)
+	symlink := filepath.Join(dir, `symlink.bin`)
+	hardlink := filepath.Join(dir, `hardlink.bin`)
+
+	err := os.WriteFile(path, []byte(`hello world`), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	save := func(name string, offset uint64, data string) {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer f.Close()
+
+		b := New(f, 11)
+		_ = b.Overwrite(offset, []byte(data))
+
+		err = Save(b, name, name+`~`)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = os.Symlink(path, symlink)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// Target of the link is replaced
+	save(symlink, 0, `J`)
+
+	err = os.Link(path, hardlink)
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// File is written in place
+	save(hardlink, 6, `W`)
+
+	fi, err := os.Lstat(symlink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Mode()&os.ModeSymlink == 0 {
+		t.Errorf(`symbolic link was replaced with a file`)
+	}
+
+	// All names are still the same file
+	expected := `Jello World`
+	for _, name := range []string{path, symlink, hardlink} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != expected {
+			t.Errorf(`%v: expected %q, got %q`, name, expected, data)
+		}
+	}
+
+	// Backups have the previous version
+	for name, prev := range map[string]string{symlink + `~`: `hello world`, hardlink + `~`: `Jello world`} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(data) != prev {
+			t.Errorf(`%v: expected %q, got %q`, name, prev, data)
+		}
+	}
+}
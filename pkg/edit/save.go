@@ -0,0 +1,137 @@
+package edit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Save writes buffer to file atomically: data is first written to a temporary file in the same directory which then
+// replaces the file. Symbolic links are followed, so the target file is replaced instead of the link.
+// Files which have hard links, or whose owner or extended attributes can't be copied to the temporary file, are
+// overwritten in place instead, so that they stay the same file. If backup is not empty, the previous file is kept
+// with that name.
+func Save(b *Buffer, path string, backup string) (err error) {
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf(`couldn't resolve %v: %w`, path, err)
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf(`couldn't stat %v: %w`, path, err)
+	}
+
+	if backup != `` {
+		err = copyFile(path, backup, fi.Mode().Perm())
+		if err != nil {
+			return fmt.Errorf(`couldn't create backup: %w`, err)
+		}
+	}
+
+	if linkCount(fi) > 1 {
+		return writeInPlace(b, path)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), `.`+filepath.Base(path)+`.heksa-*`)
+	if err != nil {
+		return fmt.Errorf(`couldn't create temporary file: %w`, err)
+	}
+
+	defer func() {
+		if err != nil {
+			// Don't leave partial file behind
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = b.WriteTo(tmp)
+	if err != nil {
+		return fmt.Errorf(`couldn't write temporary file: %w`, err)
+	}
+
+	err = tmp.Chmod(fi.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf(`couldn't set permissions: %w`, err)
+	}
+
+	if copyAttributes(fi, path, tmp) != nil {
+		// Replacing would change the owner or lose attributes
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return writeInPlace(b, path)
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		return fmt.Errorf(`couldn't sync temporary file: %w`, err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf(`couldn't close temporary file: %w`, err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf(`couldn't replace %v: %w`, path, err)
+	}
+
+	return nil
+}
+
+// writeInPlace writes only the overwritten ranges to the file. Size of the buffer never changes, so the rest of the
+// file is already correct.
+func writeInPlace(b *Buffer, path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf(`couldn't open %v for writing: %w`, path, err)
+	}
+
+	var pos uint64
+
+	for _, p := range b.pieces {
+		if p.added {
+			_, err = f.WriteAt(b.added[p.offset:p.offset+p.length], int64(pos))
+			if err != nil {
+				_ = f.Close()
+				return fmt.Errorf(`couldn't write %v: %w`, path, err)
+			}
+		}
+
+		pos += p.length
+	}
+
+	err = f.Sync()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf(`couldn't sync %v: %w`, path, err)
+	}
+
+	return f.Close()
+}
+
+// copyFile copies file src to dst, dst is overwritten
+func copyFile(src string, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
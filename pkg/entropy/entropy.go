@@ -0,0 +1,37 @@
+package entropy
+
+import (
+	"math"
+)
+
+// MaxBits is the maximum entropy of bytes in bits per byte
+const MaxBits = 8.0
+
+// Shannon returns Shannon entropy of data in bits per byte (0 - 8)
+func Shannon(data []byte) float64 {
+	var counts [256]uint64
+
+	for _, b := range data {
+		counts[b]++
+	}
+
+	return FromCounts(&counts, uint64(len(data)))
+}
+
+// FromCounts returns Shannon entropy in bits per byte (0 - 8) from count of each byte value
+func FromCounts(counts *[256]uint64, total uint64) (e float64) {
+	if total == 0 {
+		return 0
+	}
+
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+
+		p := float64(c) / float64(total)
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
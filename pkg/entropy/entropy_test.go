@@ -0,0 +1,31 @@
+package entropy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestShannon(t *testing.T) {
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+
+	tests := []struct {
+		data     []byte
+		expected float64
+	}{
+		{nil, 0},
+		{[]byte{0, 0, 0, 0}, 0},
+		{[]byte{0, 1, 0, 1}, 1},
+		{[]byte{0, 1, 2, 3}, 2},
+		{all, 8},
+	}
+
+	for _, tst := range tests {
+		got := Shannon(tst.data)
+		if math.Abs(got-tst.expected) > 1e-9 {
+			t.Errorf(`%v: expected %v, got %v`, tst.data, tst.expected, got)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/ascii"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/hex"
+)
+
+type Language uint8
+
+const (
+	LanguageC Language = iota
+	LanguageGo
+	LanguageRust
+	LanguagePython
+	LanguageJava
+)
+
+// Get enum from string
+var languageStringToEnumMap = map[string]Language{
+	`c`:      LanguageC,
+	`go`:     LanguageGo,
+	`rust`:   LanguageRust,
+	`python`: LanguagePython,
+	`java`:   LanguageJava,
+}
+
+// GetLanguage returns language from string
+func GetLanguage(s string) (Language, error) {
+	l, ok := languageStringToEnumMap[s]
+	if !ok {
+		return l, fmt.Errorf(`invalid: %q, valid: %v`, s, strings.Join(GetLanguageList(), `, `))
+	}
+
+	return l, nil
+}
+
+// GetLanguageList lists languages as strings for usage information
+func GetLanguageList() (languages []string) {
+	for s := range languageStringToEnumMap {
+		languages = append(languages, s)
+	}
+
+	sort.Strings(languages)
+	return languages
+}
+
+// DefaultName returns variable name derived from file name, for example "foo.bin" -> "foo_bin".
+// Languages where constants are upper case get upper case name.
+func DefaultName(lang Language, fname string) string {
+	var sb strings.Builder
+
+	for _, c := range fname {
+		if c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)) {
+			sb.WriteRune(c)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+
+	name := sb.String()
+	if name == `` || unicode.IsDigit(rune(name[0])) {
+		name = `_` + name
+	}
+
+	switch lang {
+	case LanguageRust, LanguageJava:
+		return strings.ToUpper(name)
+	default:
+		return name
+	}
+}
+
+// Exporter prints data as array literal of a programming language
+type Exporter struct {
+	lang    Language
+	name    string
+	width   int  // Bytes per line
+	comment bool // Add comment column with ASCII characters
+	indent  string
+	ascii   ascii.AsciiPrinter
+	sb      strings.Builder
+}
+
+func New(lang Language, name string, width int, comment bool) *Exporter {
+	e := &Exporter{
+		lang:    lang,
+		name:    name,
+		width:   width,
+		comment: comment,
+		indent:  `    `,
+		ascii:   ascii.New(),
+	}
+
+	switch lang {
+	case LanguageC:
+		e.indent = `  `
+	case LanguageGo:
+		e.indent = "\t"
+	}
+
+	return e
+}
+
+// Header returns start of variable declaration
+func (e *Exporter) Header() string {
+	switch e.lang {
+	case LanguageC:
+		return fmt.Sprintf(`unsigned char %s[] = {`, e.name)
+	case LanguageGo:
+		return fmt.Sprintf(`var %s = []byte{`, e.name)
+	case LanguageRust:
+		return fmt.Sprintf(`pub static %s: &[u8] = &[`, e.name)
+	case LanguagePython:
+		return fmt.Sprintf(`%s = bytes([`, e.name)
+	case LanguageJava:
+		return fmt.Sprintf(`public static final byte[] %s = {`, e.name)
+	default:
+		return ``
+	}
+}
+
+// Footer returns end of variable declaration. total is the count of exported bytes.
+func (e *Exporter) Footer(total uint64) string {
+	switch e.lang {
+	case LanguageC:
+		return fmt.Sprintf("};\nunsigned int %s_len = %d;", e.name, total)
+	case LanguageGo:
+		return `}`
+	case LanguageRust:
+		return `];`
+	case LanguagePython:
+		return `])`
+	case LanguageJava:
+		return `};`
+	default:
+		return ``
+	}
+}
+
+// Line returns one line of array elements
+func (e *Exporter) Line(data []byte) string {
+	e.sb.Reset()
+	e.sb.WriteString(e.indent)
+
+	for i := 0; i < e.width; i++ {
+		if i >= len(data) {
+			if !e.comment {
+				break
+			}
+
+			// Pad so that comments are aligned
+			e.sb.WriteString(strings.Repeat(` `, e.elementWidth()+1))
+		} else {
+			el := e.element(data[i])
+
+			if e.comment {
+				// Elements can have different widths (Java), align them so that comments are aligned
+				e.sb.WriteString(strings.Repeat(` `, e.elementWidth()-len(el)))
+			}
+
+			e.sb.WriteString(el)
+			e.sb.WriteString(`,`)
+		}
+
+		if i < e.width-1 {
+			e.sb.WriteString(` `)
+		}
+	}
+
+	if e.comment {
+		e.sb.WriteString(` `)
+
+		switch e.lang {
+		case LanguagePython:
+			e.sb.WriteString(`# `)
+		default:
+			e.sb.WriteString(`// `)
+		}
+
+		for _, b := range data {
+			c := e.ascii.Print(b)
+
+			if c == `\` && (e.lang == LanguageC || e.lang == LanguageJava) {
+				// Backslash would continue C comment on the next line and start unicode escape in Java
+				c = `.`
+			}
+
+			e.sb.WriteString(c)
+		}
+	}
+
+	return strings.TrimRight(e.sb.String(), ` `)
+}
+
+// element returns one array element
+func (e *Exporter) element(b byte) string {
+	if e.lang == LanguageJava && b > 0x7f {
+		// Java bytes are signed
+		return `(byte) 0x` + hex.HexByteToString[b]
+	}
+
+	return `0x` + hex.HexByteToString[b]
+}
+
+// elementWidth returns width of the widest array element
+func (e *Exporter) elementWidth() int {
+	return len(e.element(0xFF))
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommentAlignment(t *testing.T) {
+	lines := [][]byte{
+		{0x41, 0x42, 0x80, 0xFF},
+		{0x43, 0x44, 0x45, 0x46},
+		{0x47, 0x90},
+		{0x48},
+	}
+
+	for _, lang := range []Language{LanguageC, LanguageGo, LanguageRust, LanguagePython, LanguageJava} {
+		e := New(lang, `data`, 4, true)
+		column := -1
+
+		for _, data := range lines {
+			line := e.Line(data)
+
+			marker := `// `
+			if lang == LanguagePython {
+				marker = `# `
+			}
+
+			idx := strings.Index(line, marker)
+			if idx == -1 {
+				t.Fatalf(`%v: no comment in %q`, lang, line)
+			}
+
+			if column == -1 {
+				column = idx
+			} else if idx != column {
+				t.Errorf(`%v: comment at column %d, expected %d: %q`, lang, idx, column, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package extract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Encoding uint8
+
+const (
+	EncodingASCII   Encoding = iota // 7-bit printable characters
+	EncodingUTF8                    // ASCII and valid printable multi-byte UTF-8 characters
+	EncodingUTF16LE                 // 16-bit little endian, ASCII range only
+	EncodingUTF16BE                 // 16-bit big endian, ASCII range only
+)
+
+// Get enum from string
+var encodingStringToEnumMap = map[string]Encoding{
+	`ascii`:   EncodingASCII,
+	`utf8`:    EncodingUTF8,
+	`utf16le`: EncodingUTF16LE,
+	`utf16be`: EncodingUTF16BE,
+}
+
+func (e Encoding) String() string {
+	for s, en := range encodingStringToEnumMap {
+		if en == e {
+			return s
+		}
+	}
+
+	return `unknown`
+}
+
+// GetEncodings returns encodings from strings
+func GetEncodings(encodings []string) (es []Encoding, err error) {
+	for _, v := range encodings {
+		en, ok := encodingStringToEnumMap[v]
+		if !ok {
+			return nil, fmt.Errorf(`invalid: %q, valid: %v`, v, strings.Join(GetEncodingList(), `, `))
+		}
+
+		es = append(es, en)
+	}
+
+	if len(es) == 0 {
+		return nil, fmt.Errorf(`there has to be at least one encoding`)
+	}
+
+	return es, nil
+}
+
+// GetEncodingList lists encodings as strings for usage information
+func GetEncodingList() (encodings []string) {
+	for s := range encodingStringToEnumMap {
+		encodings = append(encodings, s)
+	}
+
+	sort.Strings(encodings)
+	return encodings
+}
+
+// Found is a run of printable characters
+type Found struct {
+	Offset   uint64 // Offset of the first byte
+	Encoding Encoding
+	Text     []rune
+}
+
+// scanner finds strings in one encoding, bytes are fed one at a time
+type scanner interface {
+	feed(offset uint64, b byte) []Found
+	flush() []Found
+}
+
+// run collects printable characters
+type run struct {
+	encoding  Encoding
+	minLength int
+	offset    uint64
+	text      []rune
+}
+
+func (r *run) add(offset uint64, c rune) {
+	if len(r.text) == 0 {
+		r.offset = offset
+	}
+
+	r.text = append(r.text, c)
+}
+
+// end ends the run and returns it if it's long enough
+func (r *run) end() (f []Found) {
+	if len(r.text) >= r.minLength {
+		f = append(f, Found{
+			Offset:   r.offset,
+			Encoding: r.encoding,
+			Text:     r.text,
+		})
+	}
+
+	r.text = nil
+	return f
+}
+
+// Extractor finds runs of printable characters from a stream of data
+type Extractor struct {
+	scanners []scanner
+}
+
+// New creates new extractor. printable tells which bytes (0-127) are printable characters.
+func New(encodings []Encoding, printable [256]bool, minLength int) *Extractor {
+	e := &Extractor{}
+
+	for _, enc := range encodings {
+		switch enc {
+		case EncodingASCII:
+			e.scanners = append(e.scanners, &asciiScanner{run: run{encoding: enc, minLength: minLength}, printable: printable})
+		case EncodingUTF8:
+			e.scanners = append(e.scanners, &utf8Scanner{run: run{encoding: enc, minLength: minLength}, printable: printable})
+		case EncodingUTF16LE, EncodingUTF16BE:
+			// Strings can start from even or odd offset
+			for _, odd := range []bool{false, true} {
+				e.scanners = append(e.scanners, &utf16Scanner{
+					run:       run{encoding: enc, minLength: minLength},
+					printable: printable,
+					bigEndian: enc == EncodingUTF16BE,
+					odd:       odd,
+				})
+			}
+		}
+	}
+
+	return e
+}
+
+// Feed scans data which starts at offset and returns strings which ended inside data
+func (e *Extractor) Feed(offset uint64, data []byte) (found []Found) {
+	for i, b := range data {
+		for _, s := range e.scanners {
+			found = append(found, s.feed(offset+uint64(i), b)...)
+		}
+	}
+
+	sortFound(found)
+	return found
+}
+
+// Flush returns strings which are still being collected at the end of data
+func (e *Extractor) Flush() (found []Found) {
+	for _, s := range e.scanners {
+		found = append(found, s.flush()...)
+	}
+
+	sortFound(found)
+	return found
+}
+
+func sortFound(found []Found) {
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Offset < found[j].Offset
+	})
+}
+
+type asciiScanner struct {
+	run
+	printable [256]bool
+}
+
+func (s *asciiScanner) feed(offset uint64, b byte) []Found {
+	if b < 0x80 && s.printable[b] {
+		s.add(offset, rune(b))
+		return nil
+	}
+
+	return s.end()
+}
+
+func (s *asciiScanner) flush() []Found {
+	return s.end()
+}
+
+type utf8Scanner struct {
+	run
+	printable [256]bool
+	seq       []byte // Incomplete multi-byte sequence
+	seqLength int    // Expected length of sequence
+	seqOffset uint64
+}
+
+func (s *utf8Scanner) feed(offset uint64, b byte) (f []Found) {
+	if len(s.seq) > 0 {
+		if b&0xC0 == 0x80 {
+			// Continuation byte
+			s.seq = append(s.seq, b)
+			if len(s.seq) < s.seqLength {
+				return nil
+			}
+
+			c, size := utf8.DecodeRune(s.seq)
+			s.seq = nil
+
+			if c != utf8.RuneError && size == s.seqLength && unicode.IsPrint(c) {
+				s.add(s.seqOffset, c)
+				return nil
+			}
+
+			return s.end()
+		}
+
+		// Broken sequence
+		s.seq = nil
+		f = s.end()
+	}
+
+	if b < 0x80 {
+		if s.printable[b] {
+			s.add(offset, rune(b))
+			return f
+		}
+
+		return append(f, s.end()...)
+	}
+
+	switch {
+	case b >= 0xC2 && b <= 0xDF:
+		s.seqLength = 2
+	case b >= 0xE0 && b <= 0xEF:
+		s.seqLength = 3
+	case b >= 0xF0 && b <= 0xF4:
+		s.seqLength = 4
+	default:
+		// Not a lead byte
+		return append(f, s.end()...)
+	}
+
+	s.seq = []byte{b}
+	s.seqOffset = offset
+	return f
+}
+
+func (s *utf8Scanner) flush() []Found {
+	s.seq = nil
+	return s.end()
+}
+
+type utf16Scanner struct {
+	run
+	printable   [256]bool
+	bigEndian   bool
+	odd         bool // Scan code units starting from odd offsets
+	first       byte // First byte of code unit
+	firstOffset uint64
+	hasFirst    bool
+}
+
+func (s *utf16Scanner) feed(offset uint64, b byte) []Found {
+	if !s.hasFirst {
+		if (offset%2 == 1) != s.odd {
+			// Not aligned to this scanner
+			return nil
+		}
+
+		s.first = b
+		s.firstOffset = offset
+		s.hasFirst = true
+		return nil
+	}
+
+	s.hasFirst = false
+
+	unit := uint16(s.first) | uint16(b)<<8
+	if s.bigEndian {
+		unit = uint16(s.first)<<8 | uint16(b)
+	}
+
+	if unit < 0x80 && s.printable[unit] {
+		s.add(s.firstOffset, rune(unit))
+		return nil
+	}
+
+	return s.end()
+}
+
+func (s *utf16Scanner) flush() []Found {
+	s.hasFirst = false
+	return s.end()
+}
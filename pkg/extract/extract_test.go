@@ -0,0 +1,87 @@
+package extract
+
+import (
+	"fmt"
+	"testing"
+)
+
+// printableASCII has space and visible ASCII characters
+func printableASCII() (printable [256]bool) {
+	for i := 0x20; i < 0x7F; i++ {
+		printable[i] = true
+	}
+
+	return printable
+}
+
+// extractAll feeds data to extractor in chunks of given size and returns everything found as "offset:encoding:text"
+func extractAll(encodings []Encoding, minLength int, data []byte, chunkSize int) (result []string) {
+	e := New(encodings, printableASCII(), minLength)
+
+	var found []Found
+	for i := 0; i < len(data); i += chunkSize {
+		end := i + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		found = append(found, e.Feed(uint64(i), data[i:end])...)
+	}
+
+	found = append(found, e.Flush()...)
+
+	for _, f := range found {
+		result = append(result, fmt.Sprintf(`%d:%v:%s`, f.Offset, f.Encoding, string(f.Text)))
+	}
+
+	return result
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name      string
+		encodings []Encoding
+		minLength int
+		data      string
+		expected  []string
+	}{
+		{`ascii min length`, []Encoding{EncodingASCII}, 3, "ab\x00abcd\x00xyz", []string{`3:ascii:abcd`, `8:ascii:xyz`}},
+		{`ascii rejects high bytes`, []Encoding{EncodingASCII}, 2, "ab\xc3\xa9cd", []string{`0:ascii:ab`, `4:ascii:cd`}},
+		{`utf8 multi-byte`, []Encoding{EncodingUTF8}, 4, "\x00x\xc3\xa9t\xc3\xa9\x00", []string{`1:utf8:xété`}},
+		{`utf8 four byte`, []Encoding{EncodingUTF8}, 3, "a\xf0\x9f\x98\x80b", []string{"0:utf8:a\U0001F600b"}},
+		{`utf8 broken sequence`, []Encoding{EncodingUTF8}, 2, "ab\xc3cd", []string{`0:utf8:ab`, `3:utf8:cd`}},
+		{`utf8 overlong`, []Encoding{EncodingUTF8}, 2, "ab\xc0\xafcd", []string{`0:utf8:ab`, `4:utf8:cd`}},
+		{`utf16le even`, []Encoding{EncodingUTF16LE}, 3, "a\x00b\x00c\x00\x00\x00", []string{`0:utf16le:abc`}},
+		{`utf16le odd`, []Encoding{EncodingUTF16LE}, 3, "\xffa\x00b\x00c\x00\xff", []string{`1:utf16le:abc`}},
+		{`utf16be even`, []Encoding{EncodingUTF16BE}, 3, "\x00a\x00b\x00c", []string{`0:utf16be:abc`}},
+		{`utf16be odd`, []Encoding{EncodingUTF16BE}, 3, "\xff\x00a\x00b\x00c\xff", []string{`1:utf16be:abc`}},
+		{`utf16 min length`, []Encoding{EncodingUTF16LE}, 4, "a\x00b\x00c\x00\x00\x00", nil},
+		{`ascii and utf16le`, []Encoding{EncodingASCII, EncodingUTF16LE}, 3, "abc\xffx\x00y\x00z\x00", []string{`0:ascii:abc`, `4:utf16le:xyz`}},
+	}
+
+	for _, tc := range tests {
+		// Same result regardless of how the data is split into chunks
+		for _, chunkSize := range []int{1, 2, 3, len(tc.data)} {
+			got := extractAll(tc.encodings, tc.minLength, []byte(tc.data), chunkSize)
+
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf(`%s (chunk %d): expected %q, got %q`, tc.name, chunkSize, tc.expected, got)
+			}
+		}
+	}
+}
+
+func TestGetEncodings(t *testing.T) {
+	es, err := GetEncodings([]string{`ascii`, `utf16be`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(es) != 2 || es[0] != EncodingASCII || es[1] != EncodingUTF16BE {
+		t.Errorf(`unexpected encodings %v`, es)
+	}
+
+	if _, err := GetEncodings([]string{`utf32`}); err == nil {
+		t.Error(`expected error for unknown encoding`)
+	}
+}
@@ -0,0 +1,176 @@
+package find
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	p, err := ParseHex(`7f 45 4c46 ?? 0x01 4?`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Len() != 7 {
+		t.Fail()
+	}
+
+	if !p.Match([]byte{0x7f, 0x45, 0x4c, 0x46, 0xAB, 0x01, 0x4F}) {
+		t.Fail()
+	}
+
+	if p.Match([]byte{0x7f, 0x45, 0x4c, 0x46, 0xAB, 0x01, 0x5F}) {
+		t.Fail()
+	}
+}
+
+func TestParseHexInvalid(t *testing.T) {
+	for _, s := range []string{``, `7`, `7g`, `123`} {
+		_, err := ParseHex(s)
+		if err == nil {
+			t.Errorf(`expected error for %q`, s)
+		}
+	}
+}
+
+func TestScannerAcrossChunks(t *testing.T) {
+	s := NewScanner(NewBytePattern([]byte(`abcd`)))
+
+	var got []uint64
+	got = append(got, s.Feed(0, []byte(`xxab`))...)
+	got = append(got, s.Feed(4, []byte(`cdab`))...)
+	got = append(got, s.Feed(8, []byte(`c`))...)
+	got = append(got, s.Feed(9, []byte(`dabcd`))...)
+
+	expected := []uint64{2, 6, 10}
+
+	if len(got) != len(expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf(`expected %v, got %v`, expected, got)
+		}
+	}
+}
+
+func TestTypedValueU32(t *testing.T) {
+	p, err := ParseTypedValue(`u32be:1337`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.Match([]byte{0x00, 0x00, 0x05, 0x39}) {
+		t.Fail()
+	}
+
+	p, err = ParseTypedValue(`u16:0x539`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.Match([]byte{0x39, 0x05}) {
+		t.Fail()
+	}
+}
+
+func TestTypedValueNegative(t *testing.T) {
+	p, err := ParseTypedValue(`i16le:-2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.Match([]byte{0xFE, 0xFF}) {
+		t.Fail()
+	}
+
+	_, err = ParseTypedValue(`i8:200`)
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestTypedValueFloat(t *testing.T) {
+	p, err := ParseTypedValue(`f32:3.5~0.01`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !p.Match([]byte{0x0A, 0x00, 0x60, 0x40}) { // 3.5000024
+		t.Fail()
+	}
+
+	if p.Match([]byte{0x00, 0x00, 0x80, 0x40}) { // 4.0
+		t.Fail()
+	}
+}
+
+func TestTypedValueUTF16(t *testing.T) {
+	p, err := ParseTypedValue(`utf16le:"Hi"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Len() != 4 || !p.Match([]byte{'H', 0, 'i', 0}) {
+		t.Fail()
+	}
+}
+
+func TestSearchForwardAndBackward(t *testing.T) {
+	data := make([]byte, 3*searchChunkSize)
+	// Matches at the start, over chunk boundary and at the end
+	offsets := []uint64{0, searchChunkSize - 2, uint64(len(data)) - 4}
+	for _, o := range offsets {
+		copy(data[o:], `abcd`)
+	}
+
+	r := bytes.NewReader(data)
+	p := NewBytePattern([]byte(`abcd`))
+
+	from := uint64(0)
+	for _, e := range offsets {
+		got, found, err := SearchForward(r, p, from)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !found || got != e {
+			t.Fatalf(`forward from %d: expected %d, got %d (found: %v)`, from, e, got, found)
+		}
+
+		from = got + 1
+	}
+
+	_, found, err := SearchForward(r, p, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found {
+		t.Errorf(`forward from %d: expected no match`, from)
+	}
+
+	from = uint64(len(data))
+	for i := len(offsets) - 1; i >= 0; i-- {
+		got, found, err := SearchBackward(r, p, from)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !found || got != offsets[i] {
+			t.Fatalf(`backward from %d: expected %d, got %d (found: %v)`, from, offsets[i], got, found)
+		}
+
+		from = got
+	}
+
+	_, found, err = SearchBackward(r, p, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found {
+		t.Errorf(`backward from %d: expected no match`, from)
+	}
+}
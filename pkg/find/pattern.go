@@ -0,0 +1,96 @@
+package find
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pattern is something that can be searched from data
+type Pattern interface {
+	// Len tells how many bytes are needed for matching
+	Len() int
+	// Match tells if data (always Len() bytes) matches the pattern
+	Match(data []byte) bool
+}
+
+// Check implementation
+var _ Pattern = BytePattern{}
+
+// BytePattern is a sequence of bytes where each nibble can be a wildcard
+type BytePattern struct {
+	values []byte
+	masks  []byte // bits which must match, 0x00 = whole byte is wildcard
+}
+
+// ParseHex parses pattern such as "7f 45 4c 46 ?? 01" where '?' is a wildcard nibble.
+// Spaces are optional ("7f454c46??01") and bytes may have 0x prefix.
+func ParseHex(s string) (p BytePattern, err error) {
+	for _, field := range strings.Fields(s) {
+		field = strings.TrimPrefix(strings.ToLower(field), `0x`)
+
+		if len(field)%2 != 0 {
+			return p, fmt.Errorf(`invalid byte %q in pattern, two characters per byte are required`, field)
+		}
+
+		for i := 0; i < len(field); i += 2 {
+			var value, mask byte
+
+			for _, c := range field[i : i+2] {
+				value <<= 4
+				mask <<= 4
+
+				if c == '?' {
+					continue
+				}
+
+				n, err := strconv.ParseUint(string(c), 16, 8)
+				if err != nil {
+					return p, fmt.Errorf(`invalid character %q in pattern`, c)
+				}
+
+				value |= byte(n)
+				mask |= 0x0F
+			}
+
+			p.values = append(p.values, value)
+			p.masks = append(p.masks, mask)
+		}
+	}
+
+	if len(p.values) == 0 {
+		return p, fmt.Errorf(`empty pattern`)
+	}
+
+	return p, nil
+}
+
+// NewBytePattern creates pattern which matches exactly given bytes
+func NewBytePattern(b []byte) BytePattern {
+	p := BytePattern{
+		values: make([]byte, len(b)),
+		masks:  make([]byte, len(b)),
+	}
+
+	copy(p.values, b)
+
+	for i := range p.masks {
+		p.masks[i] = 0xFF
+	}
+
+	return p
+}
+
+func (p BytePattern) Len() int {
+	return len(p.values)
+}
+
+func (p BytePattern) Match(data []byte) bool {
+	for i, v := range p.values {
+		if data[i]&p.masks[i] != v {
+			return false
+		}
+	}
+
+	return true
+}
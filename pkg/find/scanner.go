@@ -0,0 +1,50 @@
+package find
+
+// Scanner finds pattern matches from a stream of data which is fed in chunks.
+// Matches spanning over chunk boundaries are found because the tail of the previous chunk is kept.
+type Scanner struct {
+	pattern   Pattern
+	buf       []byte // tail of previously fed data
+	bufOffset uint64 // offset of buf[0]
+}
+
+func NewScanner(pattern Pattern) *Scanner {
+	return &Scanner{
+		pattern: pattern,
+	}
+}
+
+// Feed searches data which starts at offset and returns offsets of matches which end inside data.
+// If offset doesn't continue from the previous chunk, previous data is discarded.
+func (s *Scanner) Feed(offset uint64, data []byte) (matches []uint64) {
+	if offset != s.bufOffset+uint64(len(s.buf)) {
+		// Not continuous
+		s.buf = nil
+		s.bufOffset = offset
+	}
+
+	size := s.pattern.Len()
+	window := append(s.buf, data...)
+
+	for i := 0; i+size <= len(window); i++ {
+		if s.pattern.Match(window[i : i+size]) {
+			matches = append(matches, s.bufOffset+uint64(i))
+		}
+	}
+
+	// Keep the tail for the next chunk, matches starting there can't be complete yet
+	keep := size - 1
+	if keep > len(window) {
+		keep = len(window)
+	}
+
+	s.bufOffset += uint64(len(window) - keep)
+	s.buf = append([]byte{}, window[len(window)-keep:]...)
+
+	return matches
+}
+
+// Len returns length of the pattern being searched
+func (s *Scanner) Len() int {
+	return s.pattern.Len()
+}
@@ -0,0 +1,87 @@
+package find
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Size of chunks read when searching from seekable input
+const searchChunkSize = 64 * 1024
+
+// readAt reads bytes from given offset, fewer bytes are returned at the end of the input
+func readAt(r io.ReadSeeker, offset uint64, size int) ([]byte, error) {
+	_, err := r.Seek(int64(offset), io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf(`couldn't seek: %w`, err)
+	}
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	return buf[0:n], nil
+}
+
+// SearchForward returns offset of the first match starting at or after from.
+// Position of r is changed.
+func SearchForward(r io.ReadSeeker, pattern Pattern, from uint64) (offset uint64, found bool, err error) {
+	s := NewScanner(pattern)
+
+	for {
+		data, err := readAt(r, from, searchChunkSize)
+		if err != nil {
+			return 0, false, err
+		}
+
+		matches := s.Feed(from, data)
+		if len(matches) > 0 {
+			return matches[0], true, nil
+		}
+
+		if len(data) < searchChunkSize {
+			// End of input
+			return 0, false, nil
+		}
+
+		from += uint64(len(data))
+	}
+}
+
+// SearchBackward returns offset of the last match starting before from.
+// Position of r is changed.
+func SearchBackward(r io.ReadSeeker, pattern Pattern, from uint64) (offset uint64, found bool, err error) {
+	size := uint64(pattern.Len())
+
+	// Data must reach over the last possible match
+	end := from + size - 1
+
+	for end >= size {
+		start := uint64(0)
+		if end > searchChunkSize {
+			start = end - searchChunkSize
+		}
+
+		data, err := readAt(r, start, int(end-start))
+		if err != nil {
+			return 0, false, err
+		}
+
+		for i := len(data) - int(size); i >= 0; i-- {
+			if start+uint64(i) < from && pattern.Match(data[i:i+int(size)]) {
+				return start + uint64(i), true, nil
+			}
+		}
+
+		if start == 0 {
+			break
+		}
+
+		// Overlap so that matches over chunk boundary are found
+		end = start + size - 1
+	}
+
+	return 0, false, nil
+}
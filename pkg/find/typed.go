@@ -0,0 +1,184 @@
+package find
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/raspi/heksa/pkg/units"
+)
+
+// Check implementation
+var _ Pattern = FloatPattern{}
+
+// FloatPattern matches floating point value with tolerance
+type FloatPattern struct {
+	size      int // 4 or 8
+	order     binary.ByteOrder
+	value     float64
+	tolerance float64
+}
+
+func (p FloatPattern) Len() int {
+	return p.size
+}
+
+func (p FloatPattern) Match(data []byte) bool {
+	var f float64
+
+	switch p.size {
+	case 4:
+		f = float64(math.Float32frombits(p.order.Uint32(data)))
+	case 8:
+		f = math.Float64frombits(p.order.Uint64(data))
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return false
+	}
+
+	return math.Abs(f-p.value) <= p.tolerance
+}
+
+// GetTypedValueList lists types for usage information
+func GetTypedValueList() []string {
+	return []string{
+		`u8`, `i8`,
+		`u16le`, `u16be`, `i16le`, `i16be`,
+		`u32le`, `u32be`, `i32le`, `i32be`,
+		`u64le`, `u64be`, `i64le`, `i64be`,
+		`f32le`, `f32be`, `f64le`, `f64be`,
+		`ascii`, `utf8`, `utf16le`, `utf16be`,
+	}
+}
+
+// ParseTypedValue parses value with type such as "u32le:1337", "f32:3.5~0.01" or `utf16le:"Player"`.
+// If endianness is not given, little endian is used. Integers support same prefixes and units as seek and limit.
+func ParseTypedValue(s string) (Pattern, error) {
+	parts := strings.SplitN(s, `:`, 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf(`invalid value %q, format is type:value`, s)
+	}
+
+	typ, value := strings.ToLower(parts[0]), parts[1]
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if strings.HasSuffix(typ, `be`) {
+		order = binary.BigEndian
+		typ = strings.TrimSuffix(typ, `be`)
+	} else {
+		typ = strings.TrimSuffix(typ, `le`)
+	}
+
+	switch typ {
+	case `u8`, `u16`, `u32`, `u64`:
+		bits, _ := strconv.Atoi(typ[1:])
+		n, err := parseUnsigned(value, bits)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewBytePattern(intBytes(n, bits/8, order)), nil
+	case `i8`, `i16`, `i32`, `i64`:
+		bits, _ := strconv.Atoi(typ[1:])
+		n, err := units.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid integer %q: %w`, value, err)
+		}
+
+		if bits < 64 && (n < -(1<<(bits-1)) || n >= 1<<(bits-1)) {
+			return nil, fmt.Errorf(`%v doesn't fit in %d bits`, n, bits)
+		}
+
+		return NewBytePattern(intBytes(uint64(n), bits/8, order)), nil
+	case `f32`, `f64`:
+		size := 4
+		if typ == `f64` {
+			size = 8
+		}
+
+		return parseFloat(value, size, order)
+	case `ascii`, `utf8`:
+		return NewBytePattern([]byte(unquote(value))), nil
+	case `utf16`:
+		var b []byte
+		for _, u := range utf16.Encode([]rune(unquote(value))) {
+			b = append(b, intBytes(uint64(u), 2, order)...)
+		}
+
+		return NewBytePattern(b), nil
+	default:
+		return nil, fmt.Errorf(`invalid type %q, valid: %v`, parts[0], strings.Join(GetTypedValueList(), `, `))
+	}
+}
+
+// parseUnsigned parses unsigned integer which must fit in given bits
+func parseUnsigned(s string, bits int) (n uint64, err error) {
+	n, err = strconv.ParseUint(s, 0, bits)
+	if err != nil {
+		// Try with units
+		signed, uerr := units.Parse(s)
+		if uerr != nil || signed < 0 || (bits < 64 && uint64(signed) >= 1<<bits) {
+			return 0, fmt.Errorf(`invalid unsigned %d bit integer %q`, bits, s)
+		}
+
+		n = uint64(signed)
+	}
+
+	return n, nil
+}
+
+// parseFloat parses value with optional tolerance "3.5~0.01"
+func parseFloat(s string, size int, order binary.ByteOrder) (p FloatPattern, err error) {
+	p = FloatPattern{
+		size:  size,
+		order: order,
+	}
+
+	parts := strings.SplitN(s, `~`, 2)
+
+	p.value, err = strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return p, fmt.Errorf(`invalid float %q: %w`, parts[0], err)
+	}
+
+	if len(parts) == 2 {
+		p.tolerance, err = strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return p, fmt.Errorf(`invalid tolerance %q: %w`, parts[1], err)
+		}
+
+		p.tolerance = math.Abs(p.tolerance)
+	} else if size == 4 {
+		// Compare with float32 precision
+		p.value = float64(float32(p.value))
+	}
+
+	return p, nil
+}
+
+// intBytes returns n as size bytes in given byte order
+func intBytes(n uint64, size int, order binary.ByteOrder) []byte {
+	b := make([]byte, 8)
+	order.PutUint64(b, n)
+
+	if order == binary.BigEndian {
+		return b[8-size:]
+	}
+
+	return b[:size]
+}
+
+// unquote removes optional quotes around string
+func unquote(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,163 @@
+package htmlOutput
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/output"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Renderer renders dump lines as HTML.
+// Every color group is a CSS class, so colors can be changed afterwards from the stylesheet.
+type Renderer struct {
+	groupColors    map[string]uint8 // Color group -> ANSI 256 color palette index
+	byteGroups     [256]string      // Color group of each byte
+	formatterNames []string
+	splitter       string
+	isEven         bool
+	sb             strings.Builder
+}
+
+func New(groupColors map[string]uint8, byteGroups [256]string, formatterNames []string, splitter string) *Renderer {
+	return &Renderer{
+		groupColors:    groupColors,
+		byteGroups:     byteGroups,
+		formatterNames: formatterNames,
+		splitter:       splitter,
+	}
+}
+
+// Header returns start of the HTML page with stylesheet
+func (r *Renderer) Header(title string) string {
+	var groups []string
+	for name := range r.groupColors {
+		groups = append(groups, name)
+	}
+
+	sort.Strings(groups)
+
+	r.sb.Reset()
+	r.sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	r.sb.WriteString(`<title>` + output.EscapeText(title) + "</title>\n<style>\n")
+	r.sb.WriteString(fmt.Sprintf("body { background: %s; color: %s; }\n", r.css(`LineOdd`), r.css(`Default`)))
+	r.sb.WriteString("pre { font-family: monospace; line-height: 1.2; }\n")
+	r.sb.WriteString(".line { display: inline-block; }\n")
+	r.sb.WriteString("span[title]:hover { outline: 1px solid; }\n")
+
+	for _, name := range groups {
+		switch name {
+		case `LineEven`, `LineOdd`: // Background
+			r.sb.WriteString(fmt.Sprintf(".%s { background: %s; }\n", name, r.css(name)))
+		default:
+			r.sb.WriteString(fmt.Sprintf(".%s { color: %s; }\n", name, r.css(name)))
+		}
+	}
+
+	r.sb.WriteString("</style>\n</head>\n<body>\n<pre>")
+
+	return r.sb.String()
+}
+
+// Footer returns end of the HTML page
+func (r *Renderer) Footer(summary string) string {
+	return "</pre>\n<p>" + output.EscapeText(summary) + "</p>\n</body>\n</html>"
+}
+
+// Line returns one dump line.
+// offsetsLeft and offsetsRight are already formatted offsets printed on each side of the formatters.
+func (r *Renderer) Line(offset uint64, offsetsLeft []string, offsetsRight []string, data []byte, cells []base.Cell) string {
+	tooltips := r.tooltips(offset, data, cells)
+
+	r.sb.Reset()
+
+	// Change between two background colors
+	if r.isEven {
+		r.sb.WriteString(`<span class="line LineEven">`)
+	} else {
+		r.sb.WriteString(`<span class="line LineOdd">`)
+	}
+	r.isEven = !r.isEven
+
+	for _, o := range offsetsLeft {
+		r.span(`Offset`, o, ``)
+		r.span(`Splitter`, r.splitter, ``)
+	}
+
+	for _, c := range cells {
+		switch c.Kind {
+		case base.CellByte:
+			r.span(r.byteGroups[data[c.Index]], c.Text, tooltips[c.Index])
+		case base.CellPadding:
+			r.span(`Padding`, c.Text, ``)
+		case base.CellPartial:
+			r.span(`Padding`, c.Text, tooltips[c.Index])
+		case base.CellSplitter:
+			r.span(`Splitter`, c.Text, ``)
+		default:
+			r.sb.WriteString(output.EscapeText(c.Text))
+		}
+	}
+
+	for _, o := range offsetsRight {
+		r.span(`Splitter`, r.splitter, ``)
+		r.span(`Offset`, o, ``)
+	}
+
+	r.sb.WriteString(`</span>`)
+
+	return r.sb.String()
+}
+
+// tooltips returns offset and the value in every formatter for each byte
+func (r *Renderer) tooltips(offset uint64, data []byte, cells []base.Cell) []string {
+	tooltips := make([]string, len(data))
+
+	for i := range data {
+		tooltips[i] = fmt.Sprintf(`offset: 0x%x (%d)`, offset+uint64(i), offset+uint64(i))
+	}
+
+	for _, c := range cells {
+		if c.Kind != base.CellByte && c.Kind != base.CellPartial {
+			continue
+		}
+
+		name := ``
+		if c.Formatter < len(r.formatterNames) {
+			name = r.formatterNames[c.Formatter]
+		}
+
+		text := c.Text
+		if c.Kind == base.CellPartial {
+			text += ` (partial, missing bytes are zeros)`
+		}
+
+		// Multi-byte cell is shown for each of its bytes
+		for i := c.Index; i < c.Index+c.Size; i++ {
+			tooltips[i] += "\n" + name + `: ` + text
+		}
+	}
+
+	return tooltips
+}
+
+func (r *Renderer) span(class string, text string, title string) {
+	r.sb.WriteString(`<span class="` + class + `"`)
+	if title != `` {
+		// Keep one dump line on one line in the HTML source
+		r.sb.WriteString(` title="` + strings.ReplaceAll(output.EscapeText(title), "\n", `&#10;`) + `"`)
+	}
+	r.sb.WriteString(`>` + output.EscapeText(text) + `</span>`)
+}
+
+// css returns CSS color of group
+func (r *Renderer) css(group string) string {
+	idx, ok := r.groupColors[group]
+	if !ok {
+		return `inherit`
+	}
+
+	return color.ANSI256ToRGB(idx).Hex()
+}
@@ -0,0 +1,27 @@
+package htmlOutput
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+func TestLineWithoutControlCharacters(t *testing.T) {
+	r := New(map[string]uint8{`Default`: 255}, [256]string{}, []string{`bit`}, `┊`)
+
+	cells := []base.Cell{
+		{Kind: base.CellByte, Formatter: 0, Index: 0, Size: 1, Text: "\x1b[4m0100\x1b[24m0001"},
+		{Kind: base.CellSpace, Formatter: 0, Index: 0, Text: "\x1b "},
+	}
+
+	line := r.Line(0, []string{`00000000`}, nil, []byte{0x41}, cells)
+
+	if strings.ContainsRune(line, '\x1b') {
+		t.Errorf(`line contains ESC: %q`, line)
+	}
+
+	if !strings.Contains(line, `title="offset: 0x0 (0)&#10;bit: `) {
+		t.Errorf(`tooltip missing: %q`, line)
+	}
+}
@@ -0,0 +1,85 @@
+package jsonOutput
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Line is one dumped line
+type Line struct {
+	Type           string              `json:"type,omitempty"` // "line" in NDJSON output
+	Offset         uint64              `json:"offset"`
+	RelativeOffset *uint64             `json:"relative_offset,omitempty"` // Only with --print-relative-offset
+	Bytes          []int               `json:"bytes"`                     // Raw bytes as numbers, []byte would be base64 encoded
+	Cells          map[string][]string `json:"cells"`                     // Formatter name -> formatted value of each byte
+}
+
+// Summary is written after all lines
+type Summary struct {
+	Type      string `json:"type,omitempty"` // "summary" in NDJSON output
+	ReadBytes uint64 `json:"read_bytes"`
+	Lines     uint64 `json:"lines"`
+}
+
+// Writer writes lines as JSON or NDJSON.
+// JSON output is streamed as {"lines":[...],"summary":{...}} so that whole input doesn't have to fit into memory.
+type Writer struct {
+	w      io.Writer
+	ndjson bool
+	count  uint64
+}
+
+func New(w io.Writer, ndjson bool) *Writer {
+	return &Writer{
+		w:      w,
+		ndjson: ndjson,
+	}
+}
+
+// Line writes one line record
+func (w *Writer) Line(l Line) error {
+	if w.ndjson {
+		l.Type = `line`
+		w.count++
+		return w.write(``, l, "\n")
+	}
+
+	prefix := ",\n"
+	if w.count == 0 {
+		prefix = "{\"lines\":[\n"
+	}
+
+	w.count++
+
+	return w.write(prefix, l, ``)
+}
+
+// Close writes the summary and ends the output
+func (w *Writer) Close(readBytes uint64) error {
+	s := Summary{
+		ReadBytes: readBytes,
+		Lines:     w.count,
+	}
+
+	if w.ndjson {
+		s.Type = `summary`
+		return w.write(``, s, "\n")
+	}
+
+	prefix := "\n],\"summary\":"
+	if w.count == 0 {
+		prefix = `{"lines":[],"summary":`
+	}
+
+	return w.write(prefix, s, "}\n")
+}
+
+func (w *Writer) write(prefix string, v interface{}, suffix string) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w.w, prefix+string(b)+suffix)
+	return err
+}
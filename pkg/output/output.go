@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Format uint8
+
+const (
+	FormatText   Format = iota // ANSI colored text (default)
+	FormatJSON                 // One JSON document
+	FormatNDJSON               // Newline delimited JSON, one record per line
+	FormatHTML                 // Self-contained HTML page
+	FormatSVG                  // SVG image
+)
+
+// Get enum from string
+var formatStringToEnumMap = map[string]Format{
+	`text`:   FormatText,
+	`json`:   FormatJSON,
+	`ndjson`: FormatNDJSON,
+	`html`:   FormatHTML,
+	`svg`:    FormatSVG,
+}
+
+// GetFormat returns output format from string
+func GetFormat(s string) (Format, error) {
+	f, ok := formatStringToEnumMap[s]
+	if !ok {
+		return f, fmt.Errorf(`invalid: %q, valid: %v`, s, strings.Join(GetFormatList(), `, `))
+	}
+
+	return f, nil
+}
+
+// GetFormatList lists output formats as strings for usage information
+func GetFormatList() (formats []string) {
+	for s := range formatStringToEnumMap {
+		formats = append(formats, s)
+	}
+
+	sort.Strings(formats)
+	return formats
+}
+
+// EscapeText escapes text for HTML and XML.
+// Control characters other than tab and new line aren't allowed in XML, so they are replaced with U+FFFD.
+func EscapeText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r != '\t' && r != '\n' && unicode.IsControl(r) {
+			return unicode.ReplacementChar
+		}
+
+		return r
+	}, s)
+
+	return html.EscapeString(s)
+}
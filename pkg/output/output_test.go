@@ -0,0 +1,20 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := map[string]string{
+		`a<b>&"c"`:            `a&lt;b&gt;&amp;&#34;c&#34;`,
+		"\x1b[4m0100\x1b[24m": "�[4m0100�[24m",
+		"tab\tnew\nline\x00":  "tab\tnew\nline�",
+		`‡┊`:                  `‡┊`,
+	}
+
+	for s, expected := range tests {
+		if got := EscapeText(s); got != expected {
+			t.Errorf(`%q: expected %q, got %q`, s, expected, got)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package svgOutput
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/output"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+const (
+	fontSize   = 14.0
+	charWidth  = fontSize * 0.6 // Advance of one monospace character
+	lineHeight = fontSize * 1.2
+	baseline   = fontSize * 0.9 // Distance from the top of a line to the text baseline
+)
+
+// run is text in a single color group starting from a column
+type run struct {
+	column int
+	class  string
+	text   string
+}
+
+// Renderer renders dump lines as SVG image.
+// Lines are kept in memory because the image size has to be known before the lines are written.
+type Renderer struct {
+	groupColors map[string]uint8 // Color group -> ANSI 256 color palette index
+	byteGroups  [256]string      // Color group of each byte
+	splitter    string
+	lines       [][]run
+	columns     int // Width of the widest line in characters
+}
+
+func New(groupColors map[string]uint8, byteGroups [256]string, splitter string) *Renderer {
+	return &Renderer{
+		groupColors: groupColors,
+		byteGroups:  byteGroups,
+		splitter:    splitter,
+	}
+}
+
+// Line adds one dump line.
+// offsetsLeft and offsetsRight are already formatted offsets printed on each side of the formatters.
+func (r *Renderer) Line(offsetsLeft []string, offsetsRight []string, data []byte, cells []base.Cell) {
+	var runs []run
+	column := 0
+
+	add := func(class string, text string) {
+		if text == `` {
+			return
+		}
+
+		last := len(runs) - 1
+		if last >= 0 && runs[last].class == class {
+			// Merge to the previous run
+			runs[last].text += text
+		} else {
+			runs = append(runs, run{column: column, class: class, text: text})
+		}
+
+		column += utf8.RuneCountInString(text)
+	}
+
+	for _, o := range offsetsLeft {
+		add(`Offset`, o)
+		add(`Splitter`, r.splitter)
+	}
+
+	for _, c := range cells {
+		switch c.Kind {
+		case base.CellByte:
+			add(r.byteGroups[data[c.Index]], c.Text)
+		case base.CellPadding, base.CellPartial:
+			add(`Padding`, c.Text)
+		case base.CellSplitter:
+			add(`Splitter`, c.Text)
+		default:
+			// Space belongs to the previous run so that runs aren't split unnecessarily
+			class := ``
+			if len(runs) > 0 {
+				class = runs[len(runs)-1].class
+			}
+
+			add(class, c.Text)
+		}
+	}
+
+	for _, o := range offsetsRight {
+		add(`Splitter`, r.splitter)
+		add(`Offset`, o)
+	}
+
+	if column > r.columns {
+		r.columns = column
+	}
+
+	r.lines = append(r.lines, runs)
+}
+
+// WriteTo writes the SVG document
+func (r *Renderer) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+
+	width := float64(r.columns) * charWidth
+	height := float64(len(r.lines)) * lineHeight
+
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]s" height="%[2]s" viewBox="0 0 %[1]s %[2]s" font-family="monospace" font-size="%[3]s">`+"\n", num(width), num(height), num(fontSize)))
+	sb.WriteString("<style>\n")
+
+	var groups []string
+	for name := range r.groupColors {
+		groups = append(groups, name)
+	}
+
+	sort.Strings(groups)
+
+	for _, name := range groups {
+		// Line backgrounds are rectangles, so fill works for both backgrounds and text
+		sb.WriteString(fmt.Sprintf(".%s { fill: %s; }\n", name, color.ANSI256ToRGB(r.groupColors[name]).Hex()))
+	}
+
+	sb.WriteString("</style>\n")
+
+	for i, runs := range r.lines {
+		y := float64(i) * lineHeight
+
+		// Change between two background colors
+		background := `LineOdd`
+		if i%2 == 1 {
+			background = `LineEven`
+		}
+
+		sb.WriteString(fmt.Sprintf(`<rect class="%s" x="0" y="%s" width="%s" height="%s"/>`+"\n", background, num(y), num(width), num(lineHeight)))
+		sb.WriteString(fmt.Sprintf(`<text y="%s" xml:space="preserve">`, num(y+baseline)))
+
+		for _, rn := range runs {
+			// Explicit position and length keep columns aligned even if the font isn't exactly 0.6em wide
+			sb.WriteString(fmt.Sprintf(`<tspan class="%s" x="%s" textLength="%s" lengthAdjust="spacingAndGlyphs">%s</tspan>`,
+				rn.class, num(float64(rn.column)*charWidth), num(float64(utf8.RuneCountInString(rn.text))*charWidth), output.EscapeText(rn.text)))
+		}
+
+		sb.WriteString("</text>\n")
+	}
+
+	sb.WriteString("</svg>\n")
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// num formats coordinate with at most two decimals
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
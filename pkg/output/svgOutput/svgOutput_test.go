@@ -0,0 +1,39 @@
+package svgOutput
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+func TestWellFormed(t *testing.T) {
+	r := New(map[string]uint8{`Default`: 255, `LineEven`: 235, `LineOdd`: 16}, [256]string{}, `┊`)
+
+	cells := []base.Cell{
+		{Kind: base.CellByte, Formatter: 0, Index: 0, Size: 1, Text: "\x1b[4m0100\x1b[24m0001"},
+		{Kind: base.CellSpace, Formatter: 0, Index: 0, Text: ` `},
+		{Kind: base.CellByte, Formatter: 0, Index: 1, Size: 1, Text: `<&>`},
+	}
+
+	r.Line([]string{`00000000`}, nil, []byte{0x41, 0x3C}, cells)
+
+	var sb strings.Builder
+	if _, err := r.WriteTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	d := xml.NewDecoder(strings.NewReader(sb.String()))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf(`not well-formed: %v`, err)
+		}
+	}
+}
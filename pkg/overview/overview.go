@@ -0,0 +1,108 @@
+package overview
+
+import (
+	"math"
+
+	"github.com/raspi/heksa/pkg/entropy"
+)
+
+type Class uint8
+
+const (
+	ClassMixed  Class = iota // Anything else, for example code or structures
+	ClassNull                // Mostly null or EOF bytes (padding, empty space)
+	ClassText                // Mostly printable characters
+	ClassRandom              // High entropy (compressed or encrypted)
+)
+
+const (
+	NullThreshold      = 0.9 // Minimum ratio of null bytes for ClassNull
+	TextThreshold      = 0.75
+	HighEntropyBits    = 7.0 // Minimum entropy for ClassRandom
+	DefaultChunkSize   = 4096
+	DefaultChunkPerRow = 64
+)
+
+// Chunk is summary of a block of bytes
+type Chunk struct {
+	Offset         uint64
+	Size           int
+	Entropy        float64 // Bits per byte 0 - 8
+	NullRatio      float64 // 0 - 1
+	PrintableRatio float64 // 0 - 1
+}
+
+// Analyzer summarizes chunks using byte classes
+type Analyzer struct {
+	null      [256]bool
+	printable [256]bool
+}
+
+// New creates analyzer. null and printable tell which bytes belong to those classes.
+func New(null [256]bool, printable [256]bool) Analyzer {
+	return Analyzer{
+		null:      null,
+		printable: printable,
+	}
+}
+
+// Analyze returns summary of data starting at offset
+func (a Analyzer) Analyze(offset uint64, data []byte) Chunk {
+	c := Chunk{
+		Offset: offset,
+		Size:   len(data),
+	}
+
+	if len(data) == 0 {
+		return c
+	}
+
+	var counts [256]uint64
+	for _, b := range data {
+		counts[b]++
+	}
+
+	var nulls, printables uint64
+	for b, count := range counts {
+		if a.null[b] {
+			nulls += count
+		}
+
+		if a.printable[b] {
+			printables += count
+		}
+	}
+
+	c.Entropy = entropy.FromCounts(&counts, uint64(len(data)))
+	c.NullRatio = float64(nulls) / float64(len(data))
+	c.PrintableRatio = float64(printables) / float64(len(data))
+
+	return c
+}
+
+// Classify returns the class of the chunk
+func (c Chunk) Classify() Class {
+	switch {
+	case c.NullRatio >= NullThreshold:
+		return ClassNull
+	case c.PrintableRatio >= TextThreshold:
+		return ClassText
+	case c.Entropy >= HighEntropyBits:
+		return ClassRandom
+	default:
+		return ClassMixed
+	}
+}
+
+// Braille bars with 1 - 8 dots filled from the bottom
+var bars = []rune{'⡀', '⣀', '⣄', '⣤', '⣦', '⣶', '⣷', '⣿'}
+
+// Glyph returns braille bar whose count of dots is the entropy of the chunk in bits (at least one dot so that chunk is visible)
+func (c Chunk) Glyph() rune {
+	dots := int(math.Round(c.Entropy))
+	if dots < 1 {
+		dots = 1
+	}
+
+	return bars[dots-1]
+}
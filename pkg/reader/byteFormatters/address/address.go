@@ -0,0 +1,99 @@
+package address
+
+import (
+	"net"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/decode"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Check implementation
+var _ base.MultiByteFormatter = AddressPrinter{}
+
+// Kind is the type of the address or identifier
+type Kind uint8
+
+const (
+	IPv4 Kind = iota // Dotted decimal, 4 bytes
+	IPv6             // RFC 5952 text form, 16 bytes
+	MAC              // Colon separated hex, 6 bytes
+	UUID             // RFC 4122 byte order, 16 bytes
+	GUID             // Microsoft mixed-endian byte order, 16 bytes
+)
+
+// Size of each kind in bytes
+var kindSizes = map[Kind]int{
+	IPv4: net.IPv4len,
+	IPv6: net.IPv6len,
+	MAC:  6,
+	UUID: decode.UUIDSize,
+	GUID: decode.UUIDSize,
+}
+
+// Longest formatted value of each kind
+var kindPrintSizes = map[Kind]int{
+	IPv4: len(`255.255.255.255`),
+	IPv6: len(`ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff`),
+	MAC:  len(`ff:ff:ff:ff:ff:ff`),
+	UUID: len(`ffffffff-ffff-ffff-ffff-ffffffffffff`),
+	GUID: len(`ffffffff-ffff-ffff-ffff-ffffffffffff`),
+}
+
+// AddressPrinter prints network address or identifier from N bytes
+type AddressPrinter struct {
+	kind Kind
+}
+
+func New(kind Kind) AddressPrinter {
+	return AddressPrinter{
+		kind: kind,
+	}
+}
+
+// Print prints single byte as the first byte of otherwise zeroed address
+func (p AddressPrinter) Print(b byte) string {
+	data := make([]byte, p.GetByteCount())
+	data[0] = b
+	return p.PrintBytes(data)
+}
+
+func (p AddressPrinter) PrintBytes(data []byte) string {
+	var s string
+
+	switch p.kind {
+	case IPv4:
+		s = net.IP(data).String()
+	case IPv6:
+		s = net.IP(data).String()
+
+		if v4 := net.IP(data).To4(); v4 != nil {
+			// net.IP prints IPv4-mapped address without the prefix
+			s = `::ffff:` + v4.String()
+		}
+	case MAC:
+		s = net.HardwareAddr(data).String()
+	case UUID:
+		s = decode.FormatUUID(data)
+	case GUID:
+		s = decode.FormatGUID(data)
+	}
+
+	if pad := p.GetPrintSize() - len(s); pad > 0 {
+		s = strings.Repeat(` `, pad) + s
+	}
+
+	return s
+}
+
+func (p AddressPrinter) GetByteCount() int {
+	return kindSizes[p.kind]
+}
+
+func (p AddressPrinter) GetPrintSize() int {
+	return kindPrintSizes[p.kind]
+}
+
+func (p AddressPrinter) UseSplitter() bool {
+	return true
+}
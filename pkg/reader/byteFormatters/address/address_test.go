@@ -0,0 +1,65 @@
+package address
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func TestPrintBytes(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		data     string
+		expected string
+	}{
+		{IPv4, `c0a80001`, `192.168.0.1`},
+		{IPv4, `ffffffff`, `255.255.255.255`},
+		{IPv6, `00000000000000000000000000000001`, `::1`},
+		{IPv6, `00000000000000000000000000000000`, `::`},
+		{IPv6, `20010db8000000000000000000000001`, `2001:db8::1`},
+		{IPv6, `20010db8000000010000000000000001`, `2001:db8:0:1::1`},      // Longest run of zeros is compressed
+		{IPv6, `20010db8000000010001000100010001`, `2001:db8:0:1:1:1:1:1`}, // Single zero field isn't compressed
+		{IPv6, `20010000000000010000000000010001`, `2001::1:0:0:1:1`},      // First of equally long runs is compressed
+		{IPv6, `00000000000000000000ffffc0a80001`, `::ffff:192.168.0.1`},   // IPv4-mapped
+		{IPv6, `ffffffffffffffffffffffffffffffff`, `ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff`},
+		{MAC, `001122aabbcc`, `00:11:22:aa:bb:cc`},
+		{UUID, `00112233445566778899aabbccddeeff`, `00112233-4455-6677-8899-aabbccddeeff`},
+		{GUID, `33221100554477668899aabbccddeeff`, `00112233-4455-6677-8899-aabbccddeeff`},
+	}
+
+	for _, tc := range tests {
+		p := New(tc.kind)
+		data := mustDecode(tc.data)
+
+		if len(data) != p.GetByteCount() {
+			t.Fatalf(`kind %d: expected %d bytes, test has %d`, tc.kind, p.GetByteCount(), len(data))
+		}
+
+		// Right aligned
+		expected := fmt.Sprintf(`%*s`, p.GetPrintSize(), tc.expected)
+
+		got := p.PrintBytes(data)
+		if got != expected {
+			t.Errorf(`kind %d %s: expected %q, got %q`, tc.kind, tc.data, expected, got)
+		}
+
+		if len(got) != p.GetPrintSize() {
+			t.Errorf(`kind %d %s: expected width %d, got %d`, tc.kind, tc.data, p.GetPrintSize(), len(got))
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	if got := New(IPv4).Print(10); got != `       10.0.0.0` {
+		t.Errorf(`unexpected %q`, got)
+	}
+}
@@ -13,6 +13,42 @@ type ByteFormatter interface {
 	UseSplitter() bool // Formatter can enable/disable visual splitter which occurs every N bytes
 }
 
+// MultiByteFormatter formats N bytes as one cell, for example 32-bit integers.
+// Print(byte) is not used for formatters which implement this.
+type MultiByteFormatter interface {
+	ByteFormatter
+
+	// GetByteCount tells how many bytes one cell consumes
+	GetByteCount() int
+	// PrintBytes formats one cell, data is always GetByteCount() bytes
+	PrintBytes(data []byte) string
+}
+
+// getByteCount returns how many bytes formatter consumes per cell
+func getByteCount(f ByteFormatter) int {
+	if mf, ok := f.(MultiByteFormatter); ok {
+		return mf.GetByteCount()
+	}
+
+	return 1
+}
+
+// printCell formats one cell starting from data[0]
+func printCell(f ByteFormatter, data []byte) string {
+	if mf, ok := f.(MultiByteFormatter); ok {
+		return mf.PrintBytes(data[0:mf.GetByteCount()])
+	}
+
+	return f.Print(data[0])
+}
+
+// printPartialCell formats cell which continues past the end of data, missing bytes are zeros
+func printPartialCell(f ByteFormatter, data []byte) string {
+	padded := make([]byte, getByteCount(f))
+	copy(padded, data)
+	return printCell(f, padded)
+}
+
 type FormatterGroup struct {
 	palette            [256]string // color palette for characters 0-255
 	changePalette      bool
@@ -26,6 +62,7 @@ type FormatterGroup struct {
 	splitterBreak      string
 	paddingColor       string // Color for padding (EOF)
 	EofPadding         string // EOF padding character
+	MultiByteColor     string // Color of multi-byte cells which consist of different bytes
 }
 
 func New(formatters []ByteFormatter, bytePalette [256]string, splitterBreak string, paddingColor string, width uint16, visualSplitterSize uint8) FormatterGroup {
@@ -37,6 +74,12 @@ func New(formatters []ByteFormatter, bytePalette [256]string, splitterBreak stri
 		panic(`zero width`)
 	}
 
+	for _, f := range formatters {
+		if int(width)%getByteCount(f) != 0 {
+			panic(`width is not a multiple of formatter's byte count`)
+		}
+	}
+
 	return FormatterGroup{
 		palette:            bytePalette,
 		formatters:         formatters,
@@ -54,6 +97,25 @@ func New(formatters []ByteFormatter, bytePalette [256]string, splitterBreak stri
 }
 
 func (fg *FormatterGroup) Print(tmp []byte) string {
+	return fg.PrintWithOverlay(tmp, nil)
+}
+
+// PrintWithOverlay works like Print but if overlay has a non-empty color for a position, it's used instead of the byte palette color.
+// This is used for highlighting certain positions (differences, search matches, ..) regardless of the byte value.
+func (fg *FormatterGroup) PrintWithOverlay(tmp []byte, overlay []string) string {
+	return fg.PrintWithCursor(tmp, overlay, nil)
+}
+
+// Cursor marks one cell of one formatter, for example in edit mode
+type Cursor struct {
+	Formatter int    // Index of the formatter
+	Index     int    // Index of the byte
+	On        string // Written before the cell
+	Off       string // Written after the cell
+}
+
+// PrintWithCursor works like PrintWithOverlay and also marks the cell under the cursor (optional)
+func (fg *FormatterGroup) PrintWithCursor(tmp []byte, overlay []string, cursor *Cursor) string {
 	fg.sb.Reset()
 
 	paddingIndex := len(tmp)
@@ -62,41 +124,46 @@ func (fg *FormatterGroup) Print(tmp []byte) string {
 	for didx, byteFormatterType := range fg.formatters {
 		// First character to print, so always true
 		fg.changePalette = true
+		size := getByteCount(byteFormatterType)
+		isPadding := false
 
-		for i := 0; i < fg.Width; i++ {
-			if byteFormatterType.UseSplitter() && fg.visualSplitterSize != 0 && i != 0 && i%fg.visualSplitterSize == 0 {
+		for i := 0; i < fg.Width; i += size {
+			if byteFormatterType.UseSplitter() && fg.visualSplitterSize != 0 && i != 0 && i/fg.visualSplitterSize != (i-size)/fg.visualSplitterSize {
 				// Add pad for better visualization every visualSplitterSize bytes
 				fg.sb.WriteString(fg.visualSplitter)
 			}
 
-			if paddingIndex > i {
+			if paddingIndex >= i+size {
 				if i == 0 || (i > 0 && tmp[i] != tmp[i-1] && fg.palette[tmp[i]] != fg.palette[tmp[i-1]]) {
 					fg.changePalette = true
 				}
 
 				if fg.changePalette {
-					fg.sb.WriteString(fg.palette[tmp[i]])
+					fg.sb.WriteString(fg.cellColor(tmp[i:i+size], overlay, i))
 				}
 
-				fg.sb.WriteString(byteFormatterType.Print(tmp[i]))
+				fg.writeCell(printCell(byteFormatterType, tmp[i:]), cursor, didx, i, size)
+			} else if paddingIndex > i {
+				// Only part of the cell is available, it's printed with missing bytes as zeros in padding color
+				fg.sb.WriteString(fg.paddingColor)
+				isPadding = true
 
-				if i < (fg.Width-1) && byteFormatterType.GetPrintSize() > 1 {
-					fg.sb.WriteString(` `)
-				}
+				fg.writeCell(printPartialCell(byteFormatterType, tmp[i:]), cursor, didx, i, size)
 			} else {
 				// No data available, add padding
 
-				if i == paddingIndex {
+				if !isPadding {
 					// We're at start of padding
 					fg.sb.WriteString(fg.paddingColor)
+					isPadding = true
 				}
 
 				// Print padding character N times
 				fg.sb.WriteString(strings.Repeat(fg.EofPadding, byteFormatterType.GetPrintSize()))
+			}
 
-				if i < (fg.Width-1) && (byteFormatterType.GetPrintSize() > 1) {
-					fg.sb.WriteString(` `)
-				}
+			if i+size < fg.Width && byteFormatterType.GetPrintSize() > 1 {
+				fg.sb.WriteString(` `)
 			}
 		}
 
@@ -108,3 +175,106 @@ func (fg *FormatterGroup) Print(tmp []byte) string {
 
 	return fg.sb.String()
 }
+
+// writeCell writes formatted cell which starts at index i, the cursor is marked if it's in the cell
+func (fg *FormatterGroup) writeCell(text string, cursor *Cursor, formatter int, i int, size int) {
+	if cursor != nil && cursor.Formatter == formatter && cursor.Index >= i && cursor.Index < i+size {
+		fg.sb.WriteString(cursor.On)
+		fg.sb.WriteString(text)
+		fg.sb.WriteString(cursor.Off)
+		return
+	}
+
+	fg.sb.WriteString(text)
+}
+
+// cellColor returns color for cell which starts at index i.
+// Multi-byte cells get the overlay color of any of their bytes, the palette color if all bytes are the same and MultiByteColor otherwise.
+func (fg *FormatterGroup) cellColor(data []byte, overlay []string, i int) string {
+	for j := range data {
+		if i+j < len(overlay) && overlay[i+j] != `` {
+			return overlay[i+j]
+		}
+	}
+
+	for _, b := range data {
+		if b != data[0] {
+			return fg.MultiByteColor
+		}
+	}
+
+	return fg.palette[data[0]]
+}
+
+// Cells returns each cell formatted with each formatter without colors, splitters or padding.
+// First index is the formatter and second one is the cell. Partial multi-byte cell at the end is formatted with
+// missing bytes as zeros.
+func (fg *FormatterGroup) Cells(tmp []byte) [][]string {
+	cells := make([][]string, fg.formatterCount)
+
+	for didx, byteFormatterType := range fg.formatters {
+		size := getByteCount(byteFormatterType)
+		cells[didx] = make([]string, 0, (len(tmp)+size-1)/size)
+
+		for i := 0; i < len(tmp); i += size {
+			if i+size <= len(tmp) {
+				cells[didx] = append(cells[didx], printCell(byteFormatterType, tmp[i:]))
+			} else {
+				cells[didx] = append(cells[didx], printPartialCell(byteFormatterType, tmp[i:]))
+			}
+		}
+	}
+
+	return cells
+}
+
+type CellKind uint8
+
+const (
+	CellByte     CellKind = iota // Formatted byte
+	CellPadding                  // EOF padding
+	CellSpace                    // Space between bytes or visual splitter
+	CellSplitter                 // Splitter between formatters
+	CellPartial                  // Multi-byte cell which continues past the end of the data, missing bytes are zeros
+)
+
+// Cell is one part of a formatted line
+type Cell struct {
+	Kind      CellKind
+	Formatter int // Index of the formatter
+	Index     int // Index of the (first) byte (CellByte, CellPadding and CellPartial)
+	Size      int // Count of bytes in the cell (CellByte and CellPadding), available bytes for CellPartial
+	Text      string
+}
+
+// Layout returns the same line as Print but as separate parts without colors.
+// It's used for rendering to other formats than ANSI (HTML, SVG, ..).
+func (fg *FormatterGroup) Layout(tmp []byte) (cells []Cell) {
+	for didx, byteFormatterType := range fg.formatters {
+		size := getByteCount(byteFormatterType)
+
+		for i := 0; i < fg.Width; i += size {
+			if byteFormatterType.UseSplitter() && fg.visualSplitterSize != 0 && i != 0 && i/fg.visualSplitterSize != (i-size)/fg.visualSplitterSize {
+				cells = append(cells, Cell{Kind: CellSpace, Formatter: didx, Index: i, Text: fg.visualSplitter})
+			}
+
+			if i+size <= len(tmp) {
+				cells = append(cells, Cell{Kind: CellByte, Formatter: didx, Index: i, Size: size, Text: printCell(byteFormatterType, tmp[i:])})
+			} else if i < len(tmp) {
+				cells = append(cells, Cell{Kind: CellPartial, Formatter: didx, Index: i, Size: len(tmp) - i, Text: printPartialCell(byteFormatterType, tmp[i:])})
+			} else {
+				cells = append(cells, Cell{Kind: CellPadding, Formatter: didx, Index: i, Size: size, Text: strings.Repeat(fg.EofPadding, byteFormatterType.GetPrintSize())})
+			}
+
+			if i+size < fg.Width && byteFormatterType.GetPrintSize() > 1 {
+				cells = append(cells, Cell{Kind: CellSpace, Formatter: didx, Index: i, Text: ` `})
+			}
+		}
+
+		if didx < (fg.formatterCount - 1) {
+			cells = append(cells, Cell{Kind: CellSplitter, Formatter: didx, Text: fg.Splitter})
+		}
+	}
+
+	return cells
+}
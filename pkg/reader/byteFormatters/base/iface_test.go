@@ -0,0 +1,126 @@
+package base
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Check implementation
+var _ ByteFormatter = testHex{}
+var _ MultiByteFormatter = testWord{}
+
+// testHex prints byte as two hex digits
+type testHex struct{}
+
+func (p testHex) Print(b byte) string {
+	return fmt.Sprintf(`%02x`, b)
+}
+
+func (p testHex) GetPrintSize() int {
+	return 2
+}
+
+func (p testHex) UseSplitter() bool {
+	return true
+}
+
+// testWord prints two bytes as big endian 16-bit hex
+type testWord struct{}
+
+func (p testWord) Print(b byte) string {
+	return p.PrintBytes([]byte{b, 0})
+}
+
+func (p testWord) PrintBytes(data []byte) string {
+	return fmt.Sprintf(`%02x%02x`, data[0], data[1])
+}
+
+func (p testWord) GetPrintSize() int {
+	return 4
+}
+
+func (p testWord) UseSplitter() bool {
+	return true
+}
+
+func (p testWord) GetByteCount() int {
+	return 2
+}
+
+func newTestGroup() FormatterGroup {
+	fg := New([]ByteFormatter{testHex{}, testWord{}}, [256]string{}, ``, `<P>`, 8, 4)
+	fg.Splitter = `|`
+	return fg
+}
+
+func TestPrintWithCursorPartial(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		cursor   *Cursor
+		expected string
+	}{
+		{`full`, []byte{1, 2, 3, 4, 5, 6, 7, 8}, nil, `01 02 03 04  05 06 07 08|0102 0304  0506 0708`},
+		{`partial last cell`, []byte{1, 2, 3, 4, 5, 6, 7}, nil, `01 02 03 04  05 06 07 <P>‡‡|0102 0304  0506 <P>0700`},
+		{`cursor in partial cell`, []byte{1, 2, 3, 4, 5, 6, 7}, &Cursor{Formatter: 1, Index: 6, On: `[`, Off: `]`}, `01 02 03 04  05 06 07 <P>‡‡|0102 0304  0506 <P>[0700]`},
+		{`padding after partial cell`, []byte{1, 2, 3}, nil, `01 02 03 <P>‡‡  ‡‡ ‡‡ ‡‡ ‡‡|0102 <P>0300  ‡‡‡‡ ‡‡‡‡`},
+	}
+
+	for _, tc := range tests {
+		fg := newTestGroup()
+
+		if got := fg.PrintWithCursor(tc.data, nil, tc.cursor); got != tc.expected {
+			t.Errorf(`%s: expected %q, got %q`, tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestCellsPartial(t *testing.T) {
+	fg := newTestGroup()
+
+	cells := fg.Cells([]byte{1, 2, 3, 4, 5})
+
+	if got := fmt.Sprint(cells); got != `[[01 02 03 04 05] [0102 0304 0500]]` {
+		t.Errorf(`unexpected cells %v`, got)
+	}
+}
+
+func TestLayoutPartial(t *testing.T) {
+	fg := newTestGroup()
+	fg.paddingColor = ``
+
+	data := []byte{1, 2, 3, 4, 5}
+	cells := fg.Layout(data)
+
+	// Layout has the same text as Print without colors, so visual splitters are at the same positions
+	var sb strings.Builder
+	for _, c := range cells {
+		sb.WriteString(c.Text)
+	}
+
+	if expected := fg.Print(data); sb.String() != expected {
+		t.Errorf(`expected %q, got %q`, expected, sb.String())
+	}
+
+	var partial []Cell
+	for _, c := range cells {
+		if c.Kind == CellPartial {
+			partial = append(partial, c)
+		}
+	}
+
+	expected := Cell{Kind: CellPartial, Formatter: 1, Index: 4, Size: 1, Text: `0500`}
+	if len(partial) != 1 || partial[0] != expected {
+		t.Errorf(`expected partial cell %+v, got %+v`, expected, partial)
+	}
+
+	// Visual splitter is right before byte 4 in both formatters
+	for i, c := range cells {
+		if (c.Kind == CellByte || c.Kind == CellPartial) && c.Index == 4 {
+			if prev := cells[i-1]; prev.Kind != CellSpace || prev.Index != 4 || prev.Text != fg.visualSplitter {
+				t.Errorf(`formatter %d: expected visual splitter before byte 4, got %+v`, c.Formatter, prev)
+			}
+		}
+	}
+}
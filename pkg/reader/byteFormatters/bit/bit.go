@@ -1,7 +1,6 @@
 package bit
 
 import (
-	"github.com/raspi/heksa/pkg/color"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
 )
 
@@ -9,22 +8,27 @@ import (
 var _ base.ByteFormatter = BitPrinter{}
 
 type BitPrinter struct {
+	underlineOn  string // Written before the upper nibble
+	underlineOff string // Written after the upper nibble
 }
 
-func New() BitPrinter {
-	return BitPrinter{}
+func New(underlineOn string, underlineOff string) BitPrinter {
+	return BitPrinter{
+		underlineOn:  underlineOn,
+		underlineOff: underlineOff,
+	}
 }
 
 func (p BitPrinter) Print(b byte) (o string) {
 	for idx, ru := range bitByteToString[b] {
 		if idx == 0 {
-			o += color.SetUnderlineOn
+			o += p.underlineOn
 		}
 
 		o += string(ru)
 
 		if idx == 3 {
-			o += color.SetUnderlineOff
+			o += p.underlineOff
 		}
 	}
 
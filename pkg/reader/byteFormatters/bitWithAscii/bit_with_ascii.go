@@ -15,9 +15,9 @@ type BitWithAsciiPrinter struct {
 	specialBreak string
 }
 
-func New(hilightBreak string, specialBreak string) BitWithAsciiPrinter {
+func New(bits bit.BitPrinter, hilightBreak string, specialBreak string) BitWithAsciiPrinter {
 	return BitWithAsciiPrinter{
-		p:            bit.New(),
+		p:            bits,
 		hilightBreak: hilightBreak,
 		specialBreak: specialBreak,
 	}
@@ -15,9 +15,9 @@ type BitWithDecimalPrinter struct {
 	specialBreak string
 }
 
-func New(hilightBreak string, specialBreak string) BitWithDecimalPrinter {
+func New(bits bit.BitPrinter, hilightBreak string, specialBreak string) BitWithDecimalPrinter {
 	return BitWithDecimalPrinter{
-		p:            bit.New(),
+		p:            bits,
 		hilightBreak: hilightBreak,
 		specialBreak: specialBreak,
 	}
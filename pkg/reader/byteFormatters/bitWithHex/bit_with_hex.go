@@ -15,9 +15,9 @@ type BitWithHexPrinter struct {
 	specialBreak string
 }
 
-func New(hilightBreak string, specialBreak string) BitWithHexPrinter {
+func New(bits bit.BitPrinter, hilightBreak string, specialBreak string) BitWithHexPrinter {
 	return BitWithHexPrinter{
-		p:            bit.New(),
+		p:            bits,
 		hilightBreak: hilightBreak,
 		specialBreak: specialBreak,
 	}
@@ -0,0 +1,96 @@
+package float
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/decode"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Check implementation
+var _ base.MultiByteFormatter = FloatPrinter{}
+
+// Colors for special values, written at the start of the cell
+type Colors struct {
+	NaN      string
+	Inf      string
+	Denormal string
+}
+
+// FloatPrinter prints 2, 4 or 8 bytes as floating point number
+type FloatPrinter struct {
+	format decode.FloatFormat
+	order  binary.ByteOrder
+	colors Colors
+}
+
+func New(format decode.FloatFormat, order binary.ByteOrder, colors Colors) FloatPrinter {
+	return FloatPrinter{
+		format: format,
+		order:  order,
+		colors: colors,
+	}
+}
+
+// Print prints single byte as zero-extended number
+func (p FloatPrinter) Print(b byte) string {
+	data := make([]byte, p.format.Size())
+
+	if p.order == binary.ByteOrder(binary.LittleEndian) {
+		data[0] = b
+	} else {
+		data[len(data)-1] = b
+	}
+
+	return p.PrintBytes(data)
+}
+
+func (p FloatPrinter) PrintBytes(data []byte) string {
+	var bits uint64
+
+	switch p.format.Size() {
+	case 2:
+		bits = uint64(p.order.Uint16(data))
+	case 4:
+		bits = uint64(p.order.Uint32(data))
+	case 8:
+		bits = p.order.Uint64(data)
+	}
+
+	value, class := p.format.Decode(bits)
+
+	s := strconv.FormatFloat(value, 'g', p.format.Precision(), 64)
+	if pad := p.GetPrintSize() - len(s); pad > 0 {
+		s = strings.Repeat(` `, pad) + s
+	}
+
+	switch class {
+	case decode.FloatNaN:
+		return p.colors.NaN + s
+	case decode.FloatInf:
+		return p.colors.Inf + s
+	case decode.FloatDenormal:
+		return p.colors.Denormal + s
+	}
+
+	return s
+}
+
+func (p FloatPrinter) GetByteCount() int {
+	return p.format.Size()
+}
+
+// GetPrintSize returns the longest formatted value: sign, digits, decimal point and exponent (-1.234e-05)
+func (p FloatPrinter) GetPrintSize() int {
+	if p.format == decode.Float64 {
+		return p.format.Precision() + 7
+	}
+
+	return p.format.Precision() + 6
+}
+
+func (p FloatPrinter) UseSplitter() bool {
+	return true
+}
@@ -0,0 +1,60 @@
+package hexWord
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Check implementation
+var _ base.MultiByteFormatter = HexWordPrinter{}
+
+// HexWordPrinter prints 2, 4 or 8 bytes as one hexadecimal number like 'xxd -e' does for little endian
+type HexWordPrinter struct {
+	size  int
+	order binary.ByteOrder
+}
+
+func New(size int, order binary.ByteOrder) HexWordPrinter {
+	if size != 2 && size != 4 && size != 8 {
+		panic(`invalid word size`)
+	}
+
+	return HexWordPrinter{
+		size:  size,
+		order: order,
+	}
+}
+
+// Print prints single byte as zero-extended word
+func (p HexWordPrinter) Print(b byte) string {
+	return fmt.Sprintf(`%0*x`, p.GetPrintSize(), b)
+}
+
+func (p HexWordPrinter) PrintBytes(data []byte) string {
+	var v uint64
+
+	switch p.size {
+	case 2:
+		v = uint64(p.order.Uint16(data))
+	case 4:
+		v = uint64(p.order.Uint32(data))
+	case 8:
+		v = p.order.Uint64(data)
+	}
+
+	return fmt.Sprintf(`%0*x`, p.GetPrintSize(), v)
+}
+
+func (p HexWordPrinter) GetByteCount() int {
+	return p.size
+}
+
+func (p HexWordPrinter) GetPrintSize() int {
+	return p.size * 2
+}
+
+func (p HexWordPrinter) UseSplitter() bool {
+	return true
+}
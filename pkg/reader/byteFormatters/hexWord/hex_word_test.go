@@ -0,0 +1,43 @@
+package hexWord
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestPrintBytes(t *testing.T) {
+	tests := []struct {
+		size     int
+		order    binary.ByteOrder
+		data     []byte
+		expected string
+	}{
+		{2, binary.LittleEndian, []byte{0x01, 0x02}, `0201`},
+		{2, binary.BigEndian, []byte{0x01, 0x02}, `0102`},
+		{4, binary.LittleEndian, []byte{0x78, 0x56, 0x34, 0x12}, `12345678`},
+		{4, binary.BigEndian, []byte{0x78, 0x56, 0x34, 0x12}, `78563412`},
+		{4, binary.LittleEndian, []byte{0x01, 0x00, 0x00, 0x00}, `00000001`},
+		{8, binary.LittleEndian, []byte{1, 2, 3, 4, 5, 6, 7, 8}, `0807060504030201`},
+		{8, binary.BigEndian, []byte{1, 2, 3, 4, 5, 6, 7, 8}, `0102030405060708`},
+		{8, binary.BigEndian, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, `ffffffffffffffff`},
+	}
+
+	for _, tc := range tests {
+		p := New(tc.size, tc.order)
+
+		got := p.PrintBytes(tc.data)
+		if got != tc.expected {
+			t.Errorf(`%d bytes %v % x: expected %q, got %q`, tc.size, tc.order, tc.data, tc.expected, got)
+		}
+
+		if len(got) != p.GetPrintSize() {
+			t.Errorf(`%d bytes: expected width %d, got %d`, tc.size, p.GetPrintSize(), len(got))
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	if got := New(4, binary.BigEndian).Print(0xab); got != `000000ab` {
+		t.Errorf(`expected zero-extended byte, got %q`, got)
+	}
+}
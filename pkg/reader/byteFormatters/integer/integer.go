@@ -0,0 +1,81 @@
+package integer
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Check implementation
+var _ base.MultiByteFormatter = IntegerPrinter{}
+
+// Characters needed for the largest value of each size, signed includes minus sign
+var printSizes = map[int][2]int{
+	2: {5, 6},
+	4: {10, 11},
+	8: {20, 20},
+}
+
+// IntegerPrinter prints 2, 4 or 8 bytes as signed or unsigned decimal integer
+type IntegerPrinter struct {
+	size   int
+	order  binary.ByteOrder
+	signed bool
+}
+
+func New(size int, order binary.ByteOrder, signed bool) IntegerPrinter {
+	if _, ok := printSizes[size]; !ok {
+		panic(`invalid integer size`)
+	}
+
+	return IntegerPrinter{
+		size:   size,
+		order:  order,
+		signed: signed,
+	}
+}
+
+// Print prints single byte as zero-extended integer
+func (p IntegerPrinter) Print(b byte) string {
+	return fmt.Sprintf(`%*d`, p.GetPrintSize(), b)
+}
+
+func (p IntegerPrinter) PrintBytes(data []byte) string {
+	var u uint64
+	var i int64
+
+	switch p.size {
+	case 2:
+		v := p.order.Uint16(data)
+		u, i = uint64(v), int64(int16(v))
+	case 4:
+		v := p.order.Uint32(data)
+		u, i = uint64(v), int64(int32(v))
+	case 8:
+		u = p.order.Uint64(data)
+		i = int64(u)
+	}
+
+	if p.signed {
+		return fmt.Sprintf(`%*d`, p.GetPrintSize(), i)
+	}
+
+	return fmt.Sprintf(`%*d`, p.GetPrintSize(), u)
+}
+
+func (p IntegerPrinter) GetByteCount() int {
+	return p.size
+}
+
+func (p IntegerPrinter) GetPrintSize() int {
+	if p.signed {
+		return printSizes[p.size][1]
+	}
+
+	return printSizes[p.size][0]
+}
+
+func (p IntegerPrinter) UseSplitter() bool {
+	return true
+}
@@ -0,0 +1,56 @@
+package integer
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestPrintBytes(t *testing.T) {
+	tests := []struct {
+		size     int
+		order    binary.ByteOrder
+		signed   bool
+		data     []byte
+		expected string
+	}{
+		{2, binary.LittleEndian, false, []byte{0x01, 0x02}, `  513`},
+		{2, binary.BigEndian, false, []byte{0x01, 0x02}, `  258`},
+		{2, binary.LittleEndian, false, []byte{0xff, 0xff}, `65535`},
+		{2, binary.LittleEndian, true, []byte{0xff, 0xff}, `    -1`},
+		{2, binary.BigEndian, true, []byte{0x80, 0x00}, `-32768`},
+		{4, binary.LittleEndian, false, []byte{0x78, 0x56, 0x34, 0x12}, ` 305419896`},
+		{4, binary.BigEndian, false, []byte{0x78, 0x56, 0x34, 0x12}, `2018915346`},
+		{4, binary.LittleEndian, false, []byte{0xff, 0xff, 0xff, 0xff}, `4294967295`},
+		{4, binary.LittleEndian, true, []byte{0xfe, 0xff, 0xff, 0xff}, `         -2`},
+		{4, binary.BigEndian, true, []byte{0x80, 0x00, 0x00, 0x00}, `-2147483648`},
+		{8, binary.LittleEndian, false, []byte{1, 0, 0, 0, 0, 0, 0, 0}, `                   1`},
+		{8, binary.BigEndian, false, []byte{1, 0, 0, 0, 0, 0, 0, 0}, `   72057594037927936`},
+		{8, binary.LittleEndian, false, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, `18446744073709551615`},
+		{8, binary.LittleEndian, true, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, `                  -1`},
+		{8, binary.BigEndian, true, []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, `-9223372036854775808`},
+	}
+
+	for _, tc := range tests {
+		p := New(tc.size, tc.order, tc.signed)
+
+		got := p.PrintBytes(tc.data)
+		if got != tc.expected {
+			t.Errorf(`%d bytes %v signed %v % x: expected %q, got %q`, tc.size, tc.order, tc.signed, tc.data, tc.expected, got)
+		}
+
+		// Widest value fits and shorter ones are padded to the same width
+		if len(got) != p.GetPrintSize() {
+			t.Errorf(`%d bytes %v signed %v: expected width %d, got %d`, tc.size, tc.order, tc.signed, p.GetPrintSize(), len(got))
+		}
+
+		if p.GetByteCount() != tc.size {
+			t.Errorf(`expected byte count %d, got %d`, tc.size, p.GetByteCount())
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	if got := New(4, binary.LittleEndian, true).Print(0xff); got != `        255` {
+		t.Errorf(`expected zero-extended byte, got %q`, got)
+	}
+}
@@ -0,0 +1,195 @@
+package timestamp
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/raspi/heksa/pkg/decode"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Check implementation
+var _ base.MultiByteFormatter = TimestampPrinter{}
+
+// Format is the encoding of the timestamp
+type Format uint8
+
+const (
+	Unix      Format = iota // Signed seconds since 1970-01-01
+	UnixMilli               // Signed milliseconds since 1970-01-01
+	UnixNano                // Signed nanoseconds since 1970-01-01
+	FileTime                // Windows FILETIME, 100 ns intervals since 1601-01-01
+	DOS                     // MS-DOS packed date and time
+	HFS                     // Mac HFS, unsigned seconds since 1904-01-01
+	GPS                     // GPS time, unsigned seconds since 1980-01-06
+)
+
+// Timestamps outside this range are printed with the special color
+var (
+	PlausibleFrom  = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+	PlausibleUntil = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Range of seconds which can be formatted as ISO 8601 (years 0000-9999)
+var (
+	minSeconds = time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	maxSeconds = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC).Unix()
+)
+
+// Nearest times which are out of range, used instead of values which would overflow
+var (
+	tooEarly = time.Date(-1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tooLate  = time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// TimestampPrinter prints 4 or 8 bytes as ISO 8601 date and time
+type TimestampPrinter struct {
+	format  Format
+	size    int
+	order   binary.ByteOrder
+	loc     *time.Location
+	layout  string
+	special string // Color of invalid and implausible timestamps
+}
+
+func New(format Format, size int, order binary.ByteOrder, loc *time.Location, special string) TimestampPrinter {
+	layout := `2006-01-02T15:04:05`
+
+	switch format {
+	case UnixMilli:
+		layout += `.000`
+	case UnixNano:
+		layout += `.000000000`
+	case FileTime:
+		layout += `.0000000`
+	}
+
+	return TimestampPrinter{
+		format:  format,
+		size:    size,
+		order:   order,
+		loc:     loc,
+		layout:  layout + `Z07:00`,
+		special: special,
+	}
+}
+
+// Print prints single byte as zero-extended timestamp
+func (p TimestampPrinter) Print(b byte) string {
+	data := make([]byte, p.size)
+
+	if p.order == binary.ByteOrder(binary.LittleEndian) {
+		data[0] = b
+	} else {
+		data[len(data)-1] = b
+	}
+
+	return p.PrintBytes(data)
+}
+
+func (p TimestampPrinter) PrintBytes(data []byte) string {
+	var v uint64
+
+	switch p.size {
+	case 4:
+		v = uint64(p.order.Uint32(data))
+	case 8:
+		v = p.order.Uint64(data)
+	}
+
+	t, ok := p.decode(v)
+
+	if !ok {
+		return p.special + p.pad(`invalid`)
+	}
+
+	if t.Year() < 0 || t.Year() > 9999 {
+		return p.special + p.pad(`out of range`)
+	}
+
+	s := p.pad(t.In(p.loc).Format(p.layout))
+
+	if t.Before(PlausibleFrom) || !t.Before(PlausibleUntil) {
+		return p.special + s
+	}
+
+	return s
+}
+
+// decode converts raw value to time, ok is false if the value isn't a valid timestamp
+func (p TimestampPrinter) decode(v uint64) (t time.Time, ok bool) {
+	switch p.format {
+	case Unix:
+		seconds := int64(v)
+		if p.size == 4 {
+			seconds = int64(int32(v))
+		}
+
+		return sinceEpoch(decode.UnixEpoch, seconds), true
+	case UnixMilli:
+		return time.Unix(int64(v)/1000, int64(v)%1000*int64(time.Millisecond)), true
+	case UnixNano:
+		return time.Unix(0, int64(v)), true
+	case FileTime:
+		return decode.FileTime(v), true
+	case DOS:
+		return decode.DOSDateTime(uint32(v))
+	case HFS, GPS:
+		epoch := decode.HFSEpoch
+		if p.format == GPS {
+			epoch = decode.GPSEpoch
+		}
+
+		// Unsigned
+		if v > math.MaxInt64 {
+			return tooLate, true
+		}
+
+		return sinceEpoch(epoch, int64(v)), true
+	}
+
+	return t, false
+}
+
+// sinceEpoch returns time which is seconds after epoch.
+// time.Unix overflows with the largest values, so the nearest time which is out of range is returned for them.
+func sinceEpoch(epoch time.Time, seconds int64) time.Time {
+	if seconds < minSeconds-epoch.Unix() {
+		return tooEarly
+	}
+
+	if seconds > maxSeconds-epoch.Unix() {
+		return tooLate
+	}
+
+	return decode.SinceEpoch(epoch, seconds)
+}
+
+// pad right aligns s to the print size
+func (p TimestampPrinter) pad(s string) string {
+	if n := p.GetPrintSize() - len(s); n > 0 {
+		return strings.Repeat(` `, n) + s
+	}
+
+	return s
+}
+
+func (p TimestampPrinter) GetByteCount() int {
+	return p.size
+}
+
+// GetPrintSize returns length of the layout with time zone offset (+03:00)
+func (p TimestampPrinter) GetPrintSize() int {
+	if p.loc == time.UTC {
+		// Always Z
+		return len(p.layout) - len(`07:00`)
+	}
+
+	return len(p.layout) - len(`Z07:00`) + len(`+07:00`)
+}
+
+func (p TimestampPrinter) UseSplitter() bool {
+	return true
+}
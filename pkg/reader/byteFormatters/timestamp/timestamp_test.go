@@ -0,0 +1,112 @@
+package timestamp
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+const special = `!`
+
+// le and be return v as 4 or 8 bytes
+func le(size int, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b[0:size]
+}
+
+func be(size int, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b[8-size:]
+}
+
+func TestPrintBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   Format
+		size     int
+		order    binary.ByteOrder
+		data     []byte
+		expected string
+	}{
+		{`unix le`, Unix, 4, binary.LittleEndian, le(4, 1577836800), `2020-01-01T00:00:00Z`},
+		{`unix be`, Unix, 4, binary.BigEndian, be(4, 1577836800), `2020-01-01T00:00:00Z`},
+		{`unix wrong order`, Unix, 4, binary.BigEndian, le(4, 1577836800), `!1970-06-20T16:48:30Z`},
+		{`unix epoch is implausible`, Unix, 4, binary.LittleEndian, le(4, 0), `!1970-01-01T00:00:00Z`},
+		{`unix signed`, Unix, 4, binary.LittleEndian, le(4, 0xFFFFFFFF), `!1969-12-31T23:59:59Z`},
+		{`unix 64-bit signed`, Unix, 8, binary.LittleEndian, le(8, 0xFFFFFFFFFFFFFFFF), `!1969-12-31T23:59:59Z`},
+		{`unix 64-bit out of range`, Unix, 8, binary.LittleEndian, le(8, 1<<40), `!        out of range`},
+		{`unix 64-bit largest`, Unix, 8, binary.LittleEndian, le(8, 1<<63-1), `!        out of range`},
+		{`unix 64-bit smallest`, Unix, 8, binary.LittleEndian, le(8, 1<<63), `!        out of range`},
+		{`unix 32-bit overflow`, Unix, 4, binary.LittleEndian, le(4, 1<<31), `!1901-12-13T20:45:52Z`},
+		{`last plausible`, Unix, 8, binary.LittleEndian, le(8, 4102444799), `2099-12-31T23:59:59Z`},
+		{`first implausible`, Unix, 8, binary.LittleEndian, le(8, 4102444800), `!2100-01-01T00:00:00Z`},
+		{`first plausible`, Unix, 4, binary.LittleEndian, le(4, 315532800), `1980-01-01T00:00:00Z`},
+		{`unix milliseconds`, UnixMilli, 8, binary.LittleEndian, le(8, 1577836800123), `2020-01-01T00:00:00.123Z`},
+		{`unix milliseconds out of range`, UnixMilli, 8, binary.LittleEndian, le(8, 1<<62), `!            out of range`},
+		{`unix nanoseconds`, UnixNano, 8, binary.BigEndian, be(8, 1577836800123456789), `2020-01-01T00:00:00.123456789Z`},
+		{`filetime`, FileTime, 8, binary.LittleEndian, le(8, 132000000001234567), `2019-04-17T18:40:00.1234567Z`},
+		{`filetime zero`, FileTime, 8, binary.LittleEndian, le(8, 0), `!1601-01-01T00:00:00.0000000Z`},
+		{`filetime out of range`, FileTime, 8, binary.LittleEndian, le(8, 0xFFFFFFFFFFFFFFFF), `!                out of range`},
+		{`dos`, DOS, 4, binary.LittleEndian, le(4, 0x4E9E0000), `2019-04-30T00:00:00Z`},
+		{`dos invalid`, DOS, 4, binary.LittleEndian, le(4, 0x4E9F0000), `!             invalid`},
+		{`hfs epoch`, HFS, 4, binary.BigEndian, be(4, 0), `!1904-01-01T00:00:00Z`},
+		{`hfs unsigned`, HFS, 4, binary.BigEndian, be(4, 0xFFFFFFFF), `2040-02-06T06:28:15Z`},
+		{`hfs 64-bit unsigned`, HFS, 8, binary.BigEndian, be(8, 0xFFFFFFFFFFFFFFFF), `!        out of range`},
+		{`gps epoch`, GPS, 4, binary.LittleEndian, le(4, 0), `1980-01-06T00:00:00Z`},
+		{`gps 64-bit unsigned`, GPS, 8, binary.LittleEndian, le(8, 0xFFFFFFFFFFFFFFFF), `!        out of range`},
+	}
+
+	for _, tc := range tests {
+		p := New(tc.format, tc.size, tc.order, time.UTC, special)
+
+		got := p.PrintBytes(tc.data)
+		if got != tc.expected {
+			t.Errorf(`%s: expected %q, got %q`, tc.name, tc.expected, got)
+		}
+
+		// Special color isn't part of the width
+		if n := len(strings.TrimPrefix(got, special)); n != p.GetPrintSize() {
+			t.Errorf(`%s: expected width %d, got %d`, tc.name, p.GetPrintSize(), n)
+		}
+	}
+}
+
+func TestLocalTime(t *testing.T) {
+	loc := time.FixedZone(`test`, 3*60*60)
+
+	tests := []struct {
+		format   Format
+		data     []byte
+		expected string
+	}{
+		{Unix, le(8, 1577836800), `2020-01-01T03:00:00+03:00`},
+		{UnixMilli, le(8, 1577836800123), `2020-01-01T03:00:00.123+03:00`},
+		{FileTime, le(8, 0xFFFFFFFFFFFFFFFF), `!                     out of range`},
+	}
+
+	for _, tc := range tests {
+		utc := New(tc.format, 8, binary.LittleEndian, time.UTC, special)
+		local := New(tc.format, 8, binary.LittleEndian, loc, special)
+
+		// Zone offset is printed instead of Z
+		if local.GetPrintSize() != utc.GetPrintSize()+len(`+03:00`)-len(`Z`) {
+			t.Errorf(`format %d: unexpected local width %d, UTC width %d`, tc.format, local.GetPrintSize(), utc.GetPrintSize())
+		}
+
+		if got := local.PrintBytes(tc.data); got != tc.expected {
+			t.Errorf(`format %d: expected %q, got %q`, tc.format, tc.expected, got)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	// Single byte is the least significant byte in both byte orders
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		if got := New(GPS, 4, order, time.UTC, ``).Print(60); got != `1980-01-06T00:01:00Z` {
+			t.Errorf(`%v: unexpected %q`, order, got)
+		}
+	}
+}
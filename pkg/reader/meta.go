@@ -0,0 +1,83 @@
+package reader
+
+import (
+	"fmt"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/adler32"
+	metaFormatters "github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/crc32"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/entropy"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/printable"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/sum"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/xor"
+	"sort"
+	"strings"
+)
+
+type MetaFormatter uint8
+
+const (
+	MetaEntropy   MetaFormatter = iota // Shannon entropy
+	MetaPrintable                      // Percentage of printable characters
+	MetaCRC32                          // CRC-32 checksum
+	MetaAdler32                        // Adler-32 checksum
+	MetaSum                            // 8-bit sum
+	MetaXor                            // XOR of bytes
+)
+
+// Get enum from string
+var metaFormattersStringToEnumMap = map[string]MetaFormatter{
+	`ent`:     MetaEntropy,
+	`prn`:     MetaPrintable,
+	`crc32`:   MetaCRC32,
+	`adler32`: MetaAdler32,
+	`sum`:     MetaSum,
+	`xor`:     MetaXor,
+}
+
+// GetMetaFormatters parses string and returns proper formatter(s)
+func GetMetaFormatters(viewerStr []string) (formatters []MetaFormatter, err error) {
+	for _, v := range viewerStr {
+		if v == `` {
+			continue
+		}
+
+		en, ok := metaFormattersStringToEnumMap[v]
+		if !ok {
+			return nil, fmt.Errorf(`invalid: %q, valid: %v`, v, strings.Join(GetMetaViewerList(), `, `))
+		}
+
+		formatters = append(formatters, en)
+	}
+
+	return formatters, nil
+}
+
+// GetMetaViewerList lists meta formatters as strings for usage information
+func GetMetaViewerList() (viewers []string) {
+	for s := range metaFormattersStringToEnumMap {
+		viewers = append(viewers, s)
+	}
+
+	sort.Strings(viewers)
+	return viewers
+}
+
+// GetFromMetaFormatter gets implementation of given formatter
+func GetFromMetaFormatter(formatter MetaFormatter, info metaFormatters.BaseInfo) metaFormatters.MetaFormatter {
+	switch formatter {
+	case MetaEntropy:
+		return entropy.New()
+	case MetaPrintable:
+		return printable.New(info)
+	case MetaCRC32:
+		return crc32.New()
+	case MetaAdler32:
+		return adler32.New()
+	case MetaSum:
+		return sum.New()
+	case MetaXor:
+		return xor.New()
+	default:
+		return nil
+	}
+}
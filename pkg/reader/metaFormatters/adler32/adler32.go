@@ -0,0 +1,27 @@
+package adler32
+
+import (
+	"fmt"
+	"hash/adler32"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = Adler32Printer{}
+
+// Adler32Printer prints Adler-32 checksum of the line
+type Adler32Printer struct {
+}
+
+func New() Adler32Printer {
+	return Adler32Printer{}
+}
+
+func (p Adler32Printer) GetFormatWidth() int {
+	return 8
+}
+
+func (p Adler32Printer) Print(data []byte) (string, base.Level) {
+	return fmt.Sprintf(`%08x`, adler32.Checksum(data)), base.LevelNone
+}
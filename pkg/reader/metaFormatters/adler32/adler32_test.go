@@ -0,0 +1,32 @@
+package adler32
+
+import (
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{``, `00000001`},
+		{`a`, `00620062`},
+		{`Wikipedia`, `11e60398`},
+	}
+
+	p := New()
+
+	for _, tc := range tests {
+		s, level := p.Print([]byte(tc.data))
+
+		if s != tc.expected || level != base.LevelNone {
+			t.Errorf(`%q: expected %q, got %q (level %d)`, tc.data, tc.expected, s, level)
+		}
+
+		if len(s) != p.GetFormatWidth() {
+			t.Errorf(`%q: expected width %d, got %d`, tc.data, p.GetFormatWidth(), len(s))
+		}
+	}
+}
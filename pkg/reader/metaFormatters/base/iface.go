@@ -0,0 +1,22 @@
+package base
+
+// Level tells how large the value is, used for coloring
+type Level uint8
+
+const (
+	LevelNone   Level = iota // Value has no magnitude (checksums)
+	LevelLow                 // Low value
+	LevelMedium              // Medium value
+	LevelHigh                // High value
+)
+
+// MetaFormatter prints a value computed from the bytes of a whole line
+type MetaFormatter interface {
+	GetFormatWidth() int
+	Print(data []byte) (string, Level)
+}
+
+// BaseInfo contains information about bytes for meta formatters
+type BaseInfo struct {
+	Printable [256]bool // Bytes which are counted as printable
+}
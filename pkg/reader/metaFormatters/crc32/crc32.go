@@ -0,0 +1,27 @@
+package crc32
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = CRC32Printer{}
+
+// CRC32Printer prints CRC-32 (IEEE) checksum of the line
+type CRC32Printer struct {
+}
+
+func New() CRC32Printer {
+	return CRC32Printer{}
+}
+
+func (p CRC32Printer) GetFormatWidth() int {
+	return 8
+}
+
+func (p CRC32Printer) Print(data []byte) (string, base.Level) {
+	return fmt.Sprintf(`%08x`, crc32.ChecksumIEEE(data)), base.LevelNone
+}
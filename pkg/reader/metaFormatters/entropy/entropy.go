@@ -0,0 +1,42 @@
+package entropy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/raspi/heksa/pkg/entropy"
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = EntropyPrinter{}
+
+// EntropyPrinter prints Shannon entropy of the line in bits per byte
+type EntropyPrinter struct {
+}
+
+func New() EntropyPrinter {
+	return EntropyPrinter{}
+}
+
+func (p EntropyPrinter) GetFormatWidth() int {
+	return 4
+}
+
+func (p EntropyPrinter) Print(data []byte) (string, base.Level) {
+	e := entropy.Shannon(data)
+
+	// Short line can't reach 8 bits, so level is relative to the maximum entropy of the line length
+	max := math.Min(entropy.MaxBits, math.Log2(float64(len(data))))
+
+	level := base.LevelLow
+	switch {
+	case max <= 0:
+	case e/max >= 0.9:
+		level = base.LevelHigh
+	case e/max >= 0.5:
+		level = base.LevelMedium
+	}
+
+	return fmt.Sprintf(`%4.2f`, e), level
+}
@@ -0,0 +1,50 @@
+package entropy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// sequence returns n bytes cycling through count distinct values
+func sequence(n int, count int) (data []byte) {
+	for i := 0; i < n; i++ {
+		data = append(data, byte(i%count))
+	}
+
+	return data
+}
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+		level    base.Level
+	}{
+		{`empty`, nil, `0.00`, base.LevelLow},
+		{`single byte`, []byte{0x41}, `0.00`, base.LevelLow},
+		{`same bytes`, bytes.Repeat([]byte{0}, 16), `0.00`, base.LevelLow},
+		{`below medium`, append(bytes.Repeat([]byte{0}, 15), 1), `0.34`, base.LevelLow},
+		{`medium at half of the maximum`, sequence(16, 4), `2.00`, base.LevelMedium},
+		{`medium below 90%`, sequence(16, 8), `3.00`, base.LevelMedium},
+		{`high at the maximum of short line`, sequence(16, 16), `4.00`, base.LevelHigh},
+		{`high at the maximum`, sequence(256, 256), `8.00`, base.LevelHigh},
+		{`medium relative to 8 bits`, sequence(512, 32), `5.00`, base.LevelMedium},
+	}
+
+	p := New()
+
+	for _, tc := range tests {
+		s, level := p.Print(tc.data)
+
+		if s != tc.expected || level != tc.level {
+			t.Errorf(`%s: expected %q level %d, got %q level %d`, tc.name, tc.expected, tc.level, s, level)
+		}
+
+		if len(s) != p.GetFormatWidth() {
+			t.Errorf(`%s: expected width %d, got %d`, tc.name, p.GetFormatWidth(), len(s))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package printable
+
+import (
+	"fmt"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = PrintablePrinter{}
+
+// PrintablePrinter prints how many percent of the line is printable characters
+type PrintablePrinter struct {
+	info base.BaseInfo
+}
+
+func New(info base.BaseInfo) PrintablePrinter {
+	return PrintablePrinter{
+		info: info,
+	}
+}
+
+func (p PrintablePrinter) GetFormatWidth() int {
+	return 4
+}
+
+func (p PrintablePrinter) Print(data []byte) (string, base.Level) {
+	count := 0
+	for _, b := range data {
+		if p.info.Printable[b] {
+			count++
+		}
+	}
+
+	percent := 0
+	if len(data) > 0 {
+		percent = count * 100 / len(data)
+	}
+
+	level := base.LevelLow
+	switch {
+	case percent >= 75:
+		level = base.LevelHigh
+	case percent >= 25:
+		level = base.LevelMedium
+	}
+
+	return fmt.Sprintf(`%3d%%`, percent), level
+}
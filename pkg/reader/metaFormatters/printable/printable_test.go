@@ -0,0 +1,43 @@
+package printable
+
+import (
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+func TestPrint(t *testing.T) {
+	var info base.BaseInfo
+	for i := 0x20; i < 0x7F; i++ {
+		info.Printable[i] = true
+	}
+
+	tests := []struct {
+		data     string
+		expected string
+		level    base.Level
+	}{
+		{``, `  0%`, base.LevelLow},
+		{"\x00\x01\x02\x03", `  0%`, base.LevelLow},
+		{"a\x00\x00\x00\x00", ` 20%`, base.LevelLow},
+		{"a\x00\x00\x00", ` 25%`, base.LevelMedium},
+		{"ab\x00\x00", ` 50%`, base.LevelMedium},
+		{"abc\x00\x00\x00\x00\x00", ` 37%`, base.LevelMedium},
+		{"abc\x00", ` 75%`, base.LevelHigh},
+		{"abcd", `100%`, base.LevelHigh},
+	}
+
+	p := New(info)
+
+	for _, tc := range tests {
+		s, level := p.Print([]byte(tc.data))
+
+		if s != tc.expected || level != tc.level {
+			t.Errorf(`%q: expected %q level %d, got %q level %d`, tc.data, tc.expected, tc.level, s, level)
+		}
+
+		if len(s) != p.GetFormatWidth() {
+			t.Errorf(`%q: expected width %d, got %d`, tc.data, p.GetFormatWidth(), len(s))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package sum
+
+import (
+	"fmt"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = SumPrinter{}
+
+// SumPrinter prints 8-bit sum of the bytes of the line (modulo 256)
+type SumPrinter struct {
+}
+
+func New() SumPrinter {
+	return SumPrinter{}
+}
+
+func (p SumPrinter) GetFormatWidth() int {
+	return 2
+}
+
+func (p SumPrinter) Print(data []byte) (string, base.Level) {
+	var sum uint8
+	for _, b := range data {
+		sum += b
+	}
+
+	return fmt.Sprintf(`%02x`, sum), base.LevelNone
+}
@@ -0,0 +1,33 @@
+package sum
+
+import (
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected string
+	}{
+		{nil, `00`},
+		{[]byte{0x01, 0x02, 0x03}, `06`},
+		{[]byte{0xff, 0x02}, `01`}, // Modulo 256
+		{[]byte{0x80, 0x80, 0x7f}, `7f`},
+	}
+
+	p := New()
+
+	for _, tc := range tests {
+		s, level := p.Print(tc.data)
+
+		if s != tc.expected || level != base.LevelNone {
+			t.Errorf(`% x: expected %q, got %q (level %d)`, tc.data, tc.expected, s, level)
+		}
+
+		if len(s) != p.GetFormatWidth() {
+			t.Errorf(`% x: expected width %d, got %d`, tc.data, p.GetFormatWidth(), len(s))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package xor
+
+import (
+	"fmt"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+// Check implementation
+var _ base.MetaFormatter = XorPrinter{}
+
+// XorPrinter prints XOR of the bytes of the line
+type XorPrinter struct {
+}
+
+func New() XorPrinter {
+	return XorPrinter{}
+}
+
+func (p XorPrinter) GetFormatWidth() int {
+	return 2
+}
+
+func (p XorPrinter) Print(data []byte) (string, base.Level) {
+	var x uint8
+	for _, b := range data {
+		x ^= b
+	}
+
+	return fmt.Sprintf(`%02x`, x), base.LevelNone
+}
@@ -0,0 +1,34 @@
+package xor
+
+import (
+	"testing"
+
+	"github.com/raspi/heksa/pkg/reader/metaFormatters/base"
+)
+
+func TestPrint(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		expected string
+	}{
+		{nil, `00`},
+		{[]byte{0x0f}, `0f`},
+		{[]byte{0x0f, 0xf0}, `ff`},
+		{[]byte{0xaa, 0x55, 0xff}, `00`},
+		{[]byte{0x12, 0x12, 0x34}, `34`},
+	}
+
+	p := New()
+
+	for _, tc := range tests {
+		s, level := p.Print(tc.data)
+
+		if s != tc.expected || level != base.LevelNone {
+			t.Errorf(`% x: expected %q, got %q (level %d)`, tc.data, tc.expected, s, level)
+		}
+
+		if len(s) != p.GetFormatWidth() {
+			t.Errorf(`% x: expected width %d, got %d`, tc.data, p.GetFormatWidth(), len(s))
+		}
+	}
+}
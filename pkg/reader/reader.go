@@ -4,22 +4,25 @@ import (
 	"fmt"
 	"github.com/raspi/heksa/pkg/color"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+	metaFormatters "github.com/raspi/heksa/pkg/reader/metaFormatters/base"
 	offFormatters "github.com/raspi/heksa/pkg/reader/offsetFormatters/base"
 	"io"
 	"strings"
 )
 
 type ReaderColors struct {
-	LineOdd  string // background color
-	LineEven string // background color
-	Offset   string // Color of offset
-	Splitter string // Color of splitter character
+	LineOdd  string    // background color
+	LineEven string    // background color
+	Offset   string    // Color of offset
+	Splitter string    // Color of splitter character
+	Meta     [4]string // Color of each metadata level (see metaFormatters base.Level)
 }
 
 type Reader struct {
 	r                      io.ReadSeekCloser
 	offsetFormatters       []offFormatters.OffsetFormatter // offset formatters (max 2) first one is displayed on the left side and second one on the right side
 	offsetFormatterCount   int                             // shorthand for len(offsetFormatters), for speeding up
+	metaFormatters         []metaFormatters.MetaFormatter  // metadata columns computed from the line's bytes, displayed before the right side offset
 	isStdin                bool                            // Are we reading from STDIN? if so, we can't ask for offset position from file
 	readTotalBytes         uint64                          // How many bytes Reader has been reading so far (for limit)
 	readRelativeTotalBytes uint64                          // How many bytes Reader has been reading relatively
@@ -33,7 +36,7 @@ type Reader struct {
 	data                   []byte // Raw bytes data for accessing repeating data, etc
 }
 
-func New(r io.ReadSeekCloser, offsetFormatter []offFormatters.OffsetFormatter, colors ReaderColors, formatterGroup base.FormatterGroup, isStdin bool, useRelativeOffset bool) *Reader {
+func New(r io.ReadSeekCloser, offsetFormatter []offFormatters.OffsetFormatter, metaFormatter []metaFormatters.MetaFormatter, colors ReaderColors, formatterGroup base.FormatterGroup, isStdin bool, useRelativeOffset bool) *Reader {
 
 	if isStdin {
 		useRelativeOffset = false
@@ -48,6 +51,7 @@ func New(r io.ReadSeekCloser, offsetFormatter []offFormatters.OffsetFormatter, c
 		sb:                     strings.Builder{},
 		Splitter:               `┊`, // Splitter character between different columns
 		offsetFormatterCount:   len(offsetFormatter),
+		metaFormatters:         metaFormatter,
 		formatterGroup:         formatterGroup,
 		colors:                 colors,
 		isEven:                 false,
@@ -59,6 +63,10 @@ func New(r io.ReadSeekCloser, offsetFormatter []offFormatters.OffsetFormatter, c
 		reader.growHint += f.GetFormatWidth()
 	}
 
+	for _, f := range reader.metaFormatters {
+		reader.growHint += f.GetFormatWidth()
+	}
+
 	return reader
 }
 
@@ -90,42 +98,61 @@ func (r *Reader) getoffsetRight(offset uint64) string {
 	return r.sb.String()
 }
 
-// Read reads N (r.width) bytes and provides string to display
-func (r *Reader) Read() (string, error) {
-	var offset uint64
+// Line is one line of raw data read from the source
+type Line struct {
+	Offset         uint64 // Absolute offset of the first byte
+	RelativeOffset uint64 // Offset relative to the position where reading started
+	Data           []byte // Bytes read (0 - width)
+}
 
+// ReadLine reads N (r.width) bytes without formatting them
+func (r *Reader) ReadLine() (l Line, err error) {
 	if r.isStdin {
 		// reading from STDIN, can't use seek
-		offset = r.readTotalBytes
+		l.Offset = r.readTotalBytes
 	} else {
 		// Reading from file
 		offsettmp, err := r.r.Seek(0, io.SeekCurrent)
 		if err != nil {
-			return ``, fmt.Errorf(`couldn't seek: %w`, err)
+			return l, fmt.Errorf(`couldn't seek: %w`, err)
 		}
 
-		offset = uint64(offsettmp)
+		l.Offset = uint64(offsettmp)
 	}
 
-	offsetLeft := r.getoffsetLeft(offset)
-	offsetRight := r.getoffsetRight(offset)
+	l.RelativeOffset = r.readRelativeTotalBytes
 
-	offsetLeftRelative := r.getoffsetLeft(r.readRelativeTotalBytes)
-	offsetRightRelative := r.getoffsetRight(r.readRelativeTotalBytes)
-
-	r.sb.Reset()
-	r.sb.Grow(r.growHint)
-
-	// Fetch bytes with selected formatters
 	r.data = make([]byte, r.formatterGroup.Width)
 	bytesReadCount, err := r.r.Read(r.data)
 	if err != nil {
-		return ``, err
+		return l, err
 	}
 
 	r.readTotalBytes += uint64(bytesReadCount)
 	r.readRelativeTotalBytes += uint64(bytesReadCount)
 
+	l.Data = r.data[0:bytesReadCount]
+
+	return l, nil
+}
+
+// FormatLine provides string to display from given line.
+// overlay (optional) contains per-position colors which override the byte palette colors.
+func (r *Reader) FormatLine(l Line, overlay []string) string {
+	return r.FormatLineWithCursor(l, overlay, nil)
+}
+
+// FormatLineWithCursor works like FormatLine and also marks the cell under the cursor (optional)
+func (r *Reader) FormatLineWithCursor(l Line, overlay []string, cursor *base.Cursor) string {
+	offsetLeft := r.getoffsetLeft(l.Offset)
+	offsetRight := r.getoffsetRight(l.Offset)
+
+	offsetLeftRelative := r.getoffsetLeft(l.RelativeOffset)
+	offsetRightRelative := r.getoffsetRight(l.RelativeOffset)
+
+	r.sb.Reset()
+	r.sb.Grow(r.growHint)
+
 	// Change between two background colors
 	if r.isEven {
 		r.sb.WriteString(r.colors.LineEven)
@@ -143,7 +170,16 @@ func (r *Reader) Read() (string, error) {
 	}
 
 	// Print the formatted bytes
-	r.sb.WriteString(r.formatterGroup.Print(r.data[0:bytesReadCount]))
+	r.sb.WriteString(r.formatterGroup.PrintWithCursor(l.Data, overlay, cursor))
+
+	// Metadata columns
+	for _, f := range r.metaFormatters {
+		s, level := f.Print(l.Data)
+		r.sb.WriteString(r.colors.Splitter)
+		r.sb.WriteString(r.Splitter)
+		r.sb.WriteString(r.colors.Meta[level])
+		r.sb.WriteString(s)
+	}
 
 	if r.printRelativeOffset {
 		// Print relative offset
@@ -156,7 +192,40 @@ func (r *Reader) Read() (string, error) {
 	// clear ANSI code so that terminal doesn't explode
 	r.sb.WriteString(color.Clear)
 
-	return r.sb.String(), nil
+	return r.sb.String()
+}
+
+// Read reads N (r.width) bytes and provides string to display
+func (r *Reader) Read() (string, error) {
+	l, err := r.ReadLine()
+	if err != nil {
+		return ``, err
+	}
+
+	return r.FormatLine(l, nil), nil
+}
+
+// Seek moves to given offset so that the next line is read from there.
+// Relative offset moves by the same amount and line background colors are chosen by the line number so that they don't change while scrolling.
+func (r *Reader) Seek(offset uint64) error {
+	if r.isStdin {
+		return fmt.Errorf(`can't seek STDIN`)
+	}
+
+	current, err := r.r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf(`couldn't seek: %w`, err)
+	}
+
+	_, err = r.r.Seek(int64(offset), io.SeekStart)
+	if err != nil {
+		return fmt.Errorf(`couldn't seek: %w`, err)
+	}
+
+	r.readRelativeTotalBytes += offset - uint64(current)
+	r.isEven = (offset/uint64(r.formatterGroup.Width))%2 == 1
+
+	return nil
 }
 
 func (r *Reader) GetReadBytes() uint64 {
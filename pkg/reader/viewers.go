@@ -1,7 +1,10 @@
 package reader
 
 import (
+	"encoding/binary"
 	"fmt"
+	"github.com/raspi/heksa/pkg/decode"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/address"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/ascii"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/bit"
@@ -11,11 +14,16 @@ import (
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/block"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/decWithAscii"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/decimal"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/float"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/hex"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/hexWithAscii"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/hexWord"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/integer"
 	"github.com/raspi/heksa/pkg/reader/byteFormatters/octal"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/timestamp"
 	"sort"
 	"strings"
+	"time"
 )
 
 type ByteFormatter uint8
@@ -32,21 +40,126 @@ const (
 	ViewBitWithHex   // Displays bits and hex at same time
 	ViewBitWithAsc   // Displays bits and ASCII at same time
 	ViewBlock        // Display block character, for visualing patterns
+	ViewU16LE        // Unsigned 16-bit integer, little endian
+	ViewU16BE        // Unsigned 16-bit integer, big endian
+	ViewI16LE        // Signed 16-bit integer, little endian
+	ViewI16BE        // Signed 16-bit integer, big endian
+	ViewU32LE        // Unsigned 32-bit integer, little endian
+	ViewU32BE        // Unsigned 32-bit integer, big endian
+	ViewI32LE        // Signed 32-bit integer, little endian
+	ViewI32BE        // Signed 32-bit integer, big endian
+	ViewU64LE        // Unsigned 64-bit integer, little endian
+	ViewU64BE        // Unsigned 64-bit integer, big endian
+	ViewI64LE        // Signed 64-bit integer, little endian
+	ViewI64BE        // Signed 64-bit integer, big endian
+	ViewX16LE        // 16-bit hexadecimal word, little endian
+	ViewX16BE        // 16-bit hexadecimal word, big endian
+	ViewX32LE        // 32-bit hexadecimal word, little endian
+	ViewX32BE        // 32-bit hexadecimal word, big endian
+	ViewX64LE        // 64-bit hexadecimal word, little endian
+	ViewX64BE        // 64-bit hexadecimal word, big endian
+	ViewF16LE        // IEEE 754 half precision float, little endian
+	ViewF16BE        // IEEE 754 half precision float, big endian
+	ViewBF16LE       // bfloat16, little endian
+	ViewBF16BE       // bfloat16, big endian
+	ViewF32LE        // IEEE 754 single precision float, little endian
+	ViewF32BE        // IEEE 754 single precision float, big endian
+	ViewF64LE        // IEEE 754 double precision float, little endian
+	ViewF64BE        // IEEE 754 double precision float, big endian
+	ViewUnix32LE     // Unix time, signed 32-bit seconds, little endian
+	ViewUnix32BE     // Unix time, signed 32-bit seconds, big endian
+	ViewUnix64LE     // Unix time, signed 64-bit seconds, little endian
+	ViewUnix64BE     // Unix time, signed 64-bit seconds, big endian
+	ViewUnixMsLE     // Unix time, signed 64-bit milliseconds, little endian
+	ViewUnixMsBE     // Unix time, signed 64-bit milliseconds, big endian
+	ViewUnixNsLE     // Unix time, signed 64-bit nanoseconds, little endian
+	ViewUnixNsBE     // Unix time, signed 64-bit nanoseconds, big endian
+	ViewFileTimeLE   // Windows FILETIME, little endian
+	ViewFileTimeBE   // Windows FILETIME, big endian
+	ViewDOSLE        // MS-DOS date and time, little endian
+	ViewDOSBE        // MS-DOS date and time, big endian
+	ViewHFSLE        // Mac HFS time, little endian
+	ViewHFSBE        // Mac HFS time, big endian
+	ViewGPSLE        // GPS time, little endian
+	ViewGPSBE        // GPS time, big endian
+	ViewIPv4         // IPv4 address
+	ViewIPv6         // IPv6 address
+	ViewMAC          // MAC address
+	ViewUUID         // UUID in RFC 4122 byte order
+	ViewGUID         // GUID in Microsoft mixed-endian byte order
 )
 
+// FormatterColors are colors which formatters use inside the formatted cells
+type FormatterColors struct {
+	Highlight     string
+	Special       string
+	UnderlineOn   string // Upper nibble of bits
+	UnderlineOff  string
+	FloatNaN      string
+	FloatInf      string
+	FloatDenormal string
+}
+
 // Get enum from string
 var formatterStringToEnumMap = map[string]ByteFormatter{
-	`hex`:     ViewHex,
-	`asc`:     ViewASCII,
-	`bit`:     ViewBit,
-	`dec`:     ViewDec,
-	`oct`:     ViewOct,
-	`hexwasc`: ViewHexWithASCII,
-	`decwasc`: ViewDecWithASCII,
-	`bitwdec`: ViewBitWithDec,
-	`bitwhex`: ViewBitWithHex,
-	`bitwasc`: ViewBitWithAsc,
-	`blk`:     ViewBlock,
+	`hex`:        ViewHex,
+	`asc`:        ViewASCII,
+	`bit`:        ViewBit,
+	`dec`:        ViewDec,
+	`oct`:        ViewOct,
+	`hexwasc`:    ViewHexWithASCII,
+	`decwasc`:    ViewDecWithASCII,
+	`bitwdec`:    ViewBitWithDec,
+	`bitwhex`:    ViewBitWithHex,
+	`bitwasc`:    ViewBitWithAsc,
+	`blk`:        ViewBlock,
+	`u16le`:      ViewU16LE,
+	`u16be`:      ViewU16BE,
+	`i16le`:      ViewI16LE,
+	`i16be`:      ViewI16BE,
+	`u32le`:      ViewU32LE,
+	`u32be`:      ViewU32BE,
+	`i32le`:      ViewI32LE,
+	`i32be`:      ViewI32BE,
+	`u64le`:      ViewU64LE,
+	`u64be`:      ViewU64BE,
+	`i64le`:      ViewI64LE,
+	`i64be`:      ViewI64BE,
+	`x16le`:      ViewX16LE,
+	`x16be`:      ViewX16BE,
+	`x32le`:      ViewX32LE,
+	`x32be`:      ViewX32BE,
+	`x64le`:      ViewX64LE,
+	`x64be`:      ViewX64BE,
+	`f16le`:      ViewF16LE,
+	`f16be`:      ViewF16BE,
+	`bf16le`:     ViewBF16LE,
+	`bf16be`:     ViewBF16BE,
+	`f32le`:      ViewF32LE,
+	`f32be`:      ViewF32BE,
+	`f64le`:      ViewF64LE,
+	`f64be`:      ViewF64BE,
+	`unix32le`:   ViewUnix32LE,
+	`unix32be`:   ViewUnix32BE,
+	`unix64le`:   ViewUnix64LE,
+	`unix64be`:   ViewUnix64BE,
+	`unixmsle`:   ViewUnixMsLE,
+	`unixmsbe`:   ViewUnixMsBE,
+	`unixnsle`:   ViewUnixNsLE,
+	`unixnsbe`:   ViewUnixNsBE,
+	`filetimele`: ViewFileTimeLE,
+	`filetimebe`: ViewFileTimeBE,
+	`dosle`:      ViewDOSLE,
+	`dosbe`:      ViewDOSBE,
+	`hfsle`:      ViewHFSLE,
+	`hfsbe`:      ViewHFSBE,
+	`gpsle`:      ViewGPSLE,
+	`gpsbe`:      ViewGPSBE,
+	`ipv4`:       ViewIPv4,
+	`ipv6`:       ViewIPv6,
+	`mac`:        ViewMAC,
+	`uuid`:       ViewUUID,
+	`guid`:       ViewGUID,
 }
 
 // GetViewers returns viewers from string separated by ','
@@ -78,14 +191,25 @@ func GetViewerList() (viewers []string) {
 }
 
 // GetByteFormatter gets implementation of given formatter
-func GetByteFormatter(formatter ByteFormatter, hilightBreak string, specialBreak string) base.ByteFormatter {
+// Timestamps are printed in given location
+func GetByteFormatter(formatter ByteFormatter, colors FormatterColors, loc *time.Location) base.ByteFormatter {
+	hilightBreak := colors.Highlight
+	specialBreak := colors.Special
+	bits := bit.New(colors.UnderlineOn, colors.UnderlineOff)
+
+	floatColors := float.Colors{
+		NaN:      colors.FloatNaN,
+		Inf:      colors.FloatInf,
+		Denormal: colors.FloatDenormal,
+	}
+
 	switch formatter {
 	case ViewASCII:
 		return ascii.New()
 	case ViewHex:
 		return hex.New()
 	case ViewBit:
-		return bit.New()
+		return bits
 	case ViewDec:
 		return decimal.New()
 	case ViewOct:
@@ -95,13 +219,107 @@ func GetByteFormatter(formatter ByteFormatter, hilightBreak string, specialBreak
 	case ViewDecWithASCII:
 		return decWithAscii.New(hilightBreak, specialBreak)
 	case ViewBitWithAsc:
-		return bitWithAscii.New(hilightBreak, specialBreak)
+		return bitWithAscii.New(bits, hilightBreak, specialBreak)
 	case ViewBitWithDec:
-		return bitWithDecimal.New(hilightBreak, specialBreak)
+		return bitWithDecimal.New(bits, hilightBreak, specialBreak)
 	case ViewBitWithHex:
-		return bitWithHex.New(hilightBreak, specialBreak)
+		return bitWithHex.New(bits, hilightBreak, specialBreak)
 	case ViewBlock:
 		return block.New()
+	case ViewU16LE:
+		return integer.New(2, binary.LittleEndian, false)
+	case ViewU16BE:
+		return integer.New(2, binary.BigEndian, false)
+	case ViewI16LE:
+		return integer.New(2, binary.LittleEndian, true)
+	case ViewI16BE:
+		return integer.New(2, binary.BigEndian, true)
+	case ViewU32LE:
+		return integer.New(4, binary.LittleEndian, false)
+	case ViewU32BE:
+		return integer.New(4, binary.BigEndian, false)
+	case ViewI32LE:
+		return integer.New(4, binary.LittleEndian, true)
+	case ViewI32BE:
+		return integer.New(4, binary.BigEndian, true)
+	case ViewU64LE:
+		return integer.New(8, binary.LittleEndian, false)
+	case ViewU64BE:
+		return integer.New(8, binary.BigEndian, false)
+	case ViewI64LE:
+		return integer.New(8, binary.LittleEndian, true)
+	case ViewI64BE:
+		return integer.New(8, binary.BigEndian, true)
+	case ViewX16LE:
+		return hexWord.New(2, binary.LittleEndian)
+	case ViewX16BE:
+		return hexWord.New(2, binary.BigEndian)
+	case ViewX32LE:
+		return hexWord.New(4, binary.LittleEndian)
+	case ViewX32BE:
+		return hexWord.New(4, binary.BigEndian)
+	case ViewX64LE:
+		return hexWord.New(8, binary.LittleEndian)
+	case ViewX64BE:
+		return hexWord.New(8, binary.BigEndian)
+	case ViewF16LE:
+		return float.New(decode.Float16, binary.LittleEndian, floatColors)
+	case ViewF16BE:
+		return float.New(decode.Float16, binary.BigEndian, floatColors)
+	case ViewBF16LE:
+		return float.New(decode.BFloat16, binary.LittleEndian, floatColors)
+	case ViewBF16BE:
+		return float.New(decode.BFloat16, binary.BigEndian, floatColors)
+	case ViewF32LE:
+		return float.New(decode.Float32, binary.LittleEndian, floatColors)
+	case ViewF32BE:
+		return float.New(decode.Float32, binary.BigEndian, floatColors)
+	case ViewF64LE:
+		return float.New(decode.Float64, binary.LittleEndian, floatColors)
+	case ViewF64BE:
+		return float.New(decode.Float64, binary.BigEndian, floatColors)
+	case ViewUnix32LE:
+		return timestamp.New(timestamp.Unix, 4, binary.LittleEndian, loc, specialBreak)
+	case ViewUnix32BE:
+		return timestamp.New(timestamp.Unix, 4, binary.BigEndian, loc, specialBreak)
+	case ViewUnix64LE:
+		return timestamp.New(timestamp.Unix, 8, binary.LittleEndian, loc, specialBreak)
+	case ViewUnix64BE:
+		return timestamp.New(timestamp.Unix, 8, binary.BigEndian, loc, specialBreak)
+	case ViewUnixMsLE:
+		return timestamp.New(timestamp.UnixMilli, 8, binary.LittleEndian, loc, specialBreak)
+	case ViewUnixMsBE:
+		return timestamp.New(timestamp.UnixMilli, 8, binary.BigEndian, loc, specialBreak)
+	case ViewUnixNsLE:
+		return timestamp.New(timestamp.UnixNano, 8, binary.LittleEndian, loc, specialBreak)
+	case ViewUnixNsBE:
+		return timestamp.New(timestamp.UnixNano, 8, binary.BigEndian, loc, specialBreak)
+	case ViewFileTimeLE:
+		return timestamp.New(timestamp.FileTime, 8, binary.LittleEndian, loc, specialBreak)
+	case ViewFileTimeBE:
+		return timestamp.New(timestamp.FileTime, 8, binary.BigEndian, loc, specialBreak)
+	case ViewDOSLE:
+		return timestamp.New(timestamp.DOS, 4, binary.LittleEndian, loc, specialBreak)
+	case ViewDOSBE:
+		return timestamp.New(timestamp.DOS, 4, binary.BigEndian, loc, specialBreak)
+	case ViewHFSLE:
+		return timestamp.New(timestamp.HFS, 4, binary.LittleEndian, loc, specialBreak)
+	case ViewHFSBE:
+		return timestamp.New(timestamp.HFS, 4, binary.BigEndian, loc, specialBreak)
+	case ViewGPSLE:
+		return timestamp.New(timestamp.GPS, 4, binary.LittleEndian, loc, specialBreak)
+	case ViewGPSBE:
+		return timestamp.New(timestamp.GPS, 4, binary.BigEndian, loc, specialBreak)
+	case ViewIPv4:
+		return address.New(address.IPv4)
+	case ViewIPv6:
+		return address.New(address.IPv6)
+	case ViewMAC:
+		return address.New(address.MAC)
+	case ViewUUID:
+		return address.New(address.UUID)
+	case ViewGUID:
+		return address.New(address.GUID)
 	default:
 		return nil
 	}
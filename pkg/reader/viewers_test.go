@@ -0,0 +1,43 @@
+package reader
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/base"
+)
+
+// Cells and layout are used for JSON, HTML and SVG, so they must not contain ANSI codes when colors are not given
+func TestCellsWithoutColors(t *testing.T) {
+	formatters, err := GetViewers(GetViewerList())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Multiple of every cell size (1, 2, 4, 6, 8 and 16 bytes)
+	const width = 48
+
+	data := make([]byte, width)
+	for i := range data {
+		data[i] = byte(i * 37)
+	}
+
+	for idx, f := range formatters {
+		name := GetViewerList()[idx]
+		fmter := GetByteFormatter(f, FormatterColors{}, time.UTC)
+		fg := base.New([]base.ByteFormatter{fmter}, [256]string{}, ``, ``, width, 8)
+
+		for _, cell := range fg.Cells(data)[0] {
+			if strings.ContainsRune(cell, '\x1b') {
+				t.Errorf(`%s: cell %q contains ESC`, name, cell)
+			}
+		}
+
+		for _, cell := range fg.Layout(data[0 : width-5]) {
+			if strings.ContainsRune(cell.Text, '\x1b') {
+				t.Errorf(`%s: layout cell %q contains ESC`, name, cell.Text)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package regions
+
+import (
+	"github.com/raspi/heksa/pkg/overview"
+)
+
+type Kind uint8
+
+const (
+	KindPadding     Kind = iota // Null or EOF bytes
+	KindText                    // Printable characters
+	KindLowEntropy              // Structured data such as headers and tables
+	KindMixed                   // Code or structures
+	KindHighEntropy             // Compressed or encrypted
+)
+
+// Maximum entropy (bits per byte) of KindLowEntropy
+const LowEntropyBits = 3.0
+
+func (k Kind) String() string {
+	switch k {
+	case KindPadding:
+		return `padding`
+	case KindText:
+		return `text`
+	case KindLowEntropy:
+		return `low entropy`
+	case KindMixed:
+		return `mixed (code/data)`
+	case KindHighEntropy:
+		return `compressed/encrypted`
+	default:
+		return `unknown`
+	}
+}
+
+// KindOf classifies a window
+func KindOf(c overview.Chunk) Kind {
+	switch c.Classify() {
+	case overview.ClassNull:
+		return KindPadding
+	case overview.ClassText:
+		return KindText
+	case overview.ClassRandom:
+		return KindHighEntropy
+	}
+
+	if c.Entropy < LowEntropyBits {
+		return KindLowEntropy
+	}
+
+	return KindMixed
+}
+
+// Region is a range of windows of the same kind
+type Region struct {
+	Start   uint64 // Offset of the first byte
+	Size    uint64
+	Kind    Kind
+	Entropy float64 // Average entropy of the windows
+	Windows int     // Count of windows (steps when using Slider)
+}
+
+// End returns offset of the last byte
+func (r Region) End() uint64 {
+	return r.Start + r.Size - 1
+}
+
+// Segmenter merges consecutive windows of the same kind into regions
+type Segmenter struct {
+	regions []Region
+}
+
+func New() *Segmenter {
+	return &Segmenter{}
+}
+
+// Add adds next window
+func (s *Segmenter) Add(c overview.Chunk) {
+	if c.Size == 0 {
+		return
+	}
+
+	s.regions = appendRegion(s.regions, Region{
+		Start:   c.Offset,
+		Size:    uint64(c.Size),
+		Kind:    KindOf(c),
+		Entropy: c.Entropy,
+		Windows: 1,
+	})
+}
+
+// Regions returns merged regions.
+// A single window between two regions of the same kind is considered noise and merged with them.
+func (s *Segmenter) Regions() []Region {
+	var smoothed []Region
+
+	for i := 0; i < len(s.regions); i++ {
+		r := s.regions[i]
+		last := len(smoothed) - 1
+
+		if r.Windows == 1 && last >= 0 && i+1 < len(s.regions) && smoothed[last].Kind == s.regions[i+1].Kind {
+			r.Kind = smoothed[last].Kind
+		}
+
+		smoothed = appendRegion(smoothed, r)
+	}
+
+	return smoothed
+}
+
+// appendRegion appends region or merges it to the last region if they are the same kind
+func appendRegion(regions []Region, r Region) []Region {
+	last := len(regions) - 1
+
+	if last < 0 || regions[last].Kind != r.Kind {
+		return append(regions, r)
+	}
+
+	prev := &regions[last]
+	prev.Entropy = (prev.Entropy*float64(prev.Size) + r.Entropy*float64(r.Size)) / float64(prev.Size+r.Size)
+	prev.Size += r.Size
+	prev.Windows += r.Windows
+
+	return regions
+}
@@ -0,0 +1,108 @@
+package regions
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/raspi/heksa/pkg/overview"
+)
+
+func TestSegmenter(t *testing.T) {
+	s := New()
+
+	chunks := []overview.Chunk{
+		{Offset: 0, Size: 16, Entropy: 2},                     // low entropy
+		{Offset: 16, Size: 16, Entropy: 7.9},                  // high
+		{Offset: 32, Size: 16, Entropy: 7.9},                  // high
+		{Offset: 48, Size: 16, Entropy: 0, NullRatio: 1},      // noise
+		{Offset: 64, Size: 16, Entropy: 7.9},                  // high
+		{Offset: 80, Size: 16, Entropy: 0, NullRatio: 1},      // padding
+		{Offset: 96, Size: 10, Entropy: 0, NullRatio: 1},      // padding
+		{Offset: 106, Size: 0, Entropy: 0, PrintableRatio: 1}, // empty, ignored
+	}
+
+	for _, c := range chunks {
+		s.Add(c)
+	}
+
+	expected := []Region{
+		{Start: 0, Size: 16, Kind: KindLowEntropy},
+		{Start: 16, Size: 64, Kind: KindHighEntropy},
+		{Start: 80, Size: 26, Kind: KindPadding},
+	}
+
+	got := s.Regions()
+	if len(got) != len(expected) {
+		t.Fatalf(`expected %d regions, got %+v`, len(expected), got)
+	}
+
+	for i, r := range got {
+		if r.Start != expected[i].Start || r.Size != expected[i].Size || r.Kind != expected[i].Kind {
+			t.Errorf(`region %d: expected %+v, got %+v`, i, expected[i], r)
+		}
+	}
+}
+
+func TestSlider(t *testing.T) {
+	var null, printable [256]bool
+	null[0] = true
+	for i := 0x20; i < 0x7F; i++ {
+		printable[i] = true
+	}
+
+	analyzer := overview.New(null, printable)
+
+	// Boundary isn't at a multiple of the window size
+	data := append(bytes.Repeat([]byte{0}, 1000), bytes.Repeat([]byte(`abcdefgh`), 125)...)
+
+	const window = 256
+
+	whole := NewSlider(analyzer, 0x100, window)
+	whole.Feed(data)
+	whole.Flush()
+
+	expected := whole.Regions()
+
+	if len(expected) < 2 || expected[0].Kind != KindPadding || expected[len(expected)-1].Kind != KindText {
+		t.Fatalf(`unexpected regions %+v`, expected)
+	}
+
+	if expected[0].Start != 0x100 {
+		t.Errorf(`expected first region to start at 0x100, got %#x`, expected[0].Start)
+	}
+
+	// Boundary is within half of the window, short transition regions can be between
+	if end := expected[0].End() + 1; end+window/2 < 0x100+1000 || end > 0x100+1000 {
+		t.Errorf(`expected padding to end near %#x, got %#x`, 0x100+1000, end)
+	}
+
+	last := expected[len(expected)-1]
+	if last.Start < 0x100+1000 || last.Start > 0x100+1000+window/2 {
+		t.Errorf(`expected text to start near %#x, got %#x`, 0x100+1000, last.Start)
+	}
+
+	if last.End() != 0x100+uint64(len(data))-1 {
+		t.Errorf(`expected last region to end at %#x, got %#x`, 0x100+len(data)-1, last.End())
+	}
+
+	// Same result regardless of how the data is split into pieces
+	for _, pieceSize := range []int{1, 7, 100, window} {
+		s := NewSlider(analyzer, 0x100, window)
+
+		for i := 0; i < len(data); i += pieceSize {
+			end := i + pieceSize
+			if end > len(data) {
+				end = len(data)
+			}
+
+			s.Feed(data[i:end])
+		}
+
+		s.Flush()
+
+		if got := s.Regions(); fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Errorf(`piece size %d: expected %+v, got %+v`, pieceSize, expected, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package regions
+
+import (
+	"github.com/raspi/heksa/pkg/overview"
+)
+
+// StepsPerWindow is how many steps the window slides over its own size
+const StepsPerWindow = 4
+
+// Slider classifies data every step with a window centered on the step, so that region boundaries are found
+// with the accuracy of a step (quarter of the window) instead of the whole window
+type Slider struct {
+	analyzer  overview.Analyzer
+	window    uint64
+	step      uint64
+	first     uint64 // Offset of the first byte
+	start     uint64 // Offset of data[0]
+	end       uint64 // Offset after the last fed byte
+	next      uint64 // Offset of the next step to classify
+	data      []byte // Bytes still needed by the windows of the next steps
+	segmenter *Segmenter
+}
+
+// NewSlider creates slider for data starting at offset
+func NewSlider(analyzer overview.Analyzer, offset uint64, window uint64) *Slider {
+	step := window / StepsPerWindow
+	if step == 0 {
+		step = 1
+	}
+
+	return &Slider{
+		analyzer:  analyzer,
+		window:    window,
+		step:      step,
+		first:     offset,
+		start:     offset,
+		end:       offset,
+		next:      offset,
+		segmenter: New(),
+	}
+}
+
+// Feed adds bytes which continue from the previously fed bytes
+func (s *Slider) Feed(data []byte) {
+	s.data = append(s.data, data...)
+	s.end += uint64(len(data))
+	s.classify(false)
+
+	// Drop bytes which no window of the next steps can reach, the last windows can be moved back by Flush
+	keep := s.first
+	if s.next-s.first > s.window {
+		keep = s.next - s.window
+	}
+
+	s.data = append(s.data[:0], s.data[keep-s.start:]...)
+	s.start = keep
+}
+
+// Flush classifies the rest of the data, call after the last Feed
+func (s *Slider) Flush() {
+	s.classify(true)
+}
+
+// Regions returns merged regions, see Segmenter.Regions
+func (s *Slider) Regions() []Region {
+	return s.segmenter.Regions()
+}
+
+// windowOf returns the range of the window centered on the step starting at offset
+func (s *Slider) windowOf(offset uint64) (start uint64, end uint64) {
+	start = s.first
+	if center := offset + s.step/2; center-s.first > s.window/2 {
+		start = center - s.window/2
+	}
+
+	return start, start + s.window
+}
+
+// classify adds steps whose window is available. If final is set, the last step can be shorter and
+// windows which don't fit are moved back to end at the last byte.
+func (s *Slider) classify(final bool) {
+	for s.next < s.end {
+		size := s.end - s.next
+		if size > s.step {
+			size = s.step
+		}
+
+		ws, we := s.windowOf(s.next)
+
+		if we > s.end {
+			if !final {
+				return
+			}
+
+			we = s.end
+
+			ws = s.first
+			if we-s.first > s.window {
+				ws = we - s.window
+			}
+		}
+
+		c := s.analyzer.Analyze(ws, s.data[ws-s.start:we-s.start])
+		c.Offset = s.next
+		c.Size = int(size)
+		s.segmenter.Add(c)
+
+		s.next += size
+	}
+}
@@ -0,0 +1,330 @@
+package reverse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Output receives parsed data with absolute offsets
+type Output func(offset uint64, data []byte) error
+
+var (
+	ansiMatcher     = regexp.MustCompile("\x1b\\[[0-9;]*m")
+	repeatedMatcher = regexp.MustCompile(`^-- last line repeated (\d+) times`)
+	totalMatcher    = regexp.MustCompile(`^Read (\d+) bytes total`)
+	xxdMatcher      = regexp.MustCompile(`^([0-9a-fA-F]+):\s(.*)$`)
+	offsetMatcher   = regexp.MustCompile(`^([0-9a-fA-F]+)(\s.*)?$`)
+)
+
+const (
+	heksaSplitter = `┊`
+	heksaPadding  = `‡`
+)
+
+// parser keeps track of position and repeated data
+type parser struct {
+	out         Output
+	pos         uint64 // Offset where next data goes
+	last        []byte // Data of the last line for repeating
+	repeat      int    // Pending repeat count from heksa's "last line repeated N times" marker
+	squeeze     bool   // Pending '*' line from hexdump, od and xxd -a
+	firstOffset uint64
+	hasFirst    bool
+	total       int64 // Total bytes from heksa's footer, -1 if unknown
+}
+
+// Parse parses text dump and writes data to out.
+// Supported formats are heksa's own output (hex formatter with hex or no offsets), xxd, od -Ax -tx1 and hexdump -C.
+func Parse(src io.Reader, out Output) error {
+	p := &parser{
+		out:   out,
+		total: -1,
+	}
+
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		err := p.parseLine(scanner.Text())
+		if err != nil {
+			return fmt.Errorf(`line %d: %w`, lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return p.finish()
+}
+
+func (p *parser) parseLine(line string) error {
+	line = strings.TrimSpace(ansiMatcher.ReplaceAllString(line, ``))
+
+	if line == `` {
+		return nil
+	}
+
+	if m := repeatedMatcher.FindStringSubmatch(line); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return err
+		}
+
+		p.repeat = n
+		return nil
+	}
+
+	if m := totalMatcher.FindStringSubmatch(line); m != nil {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return err
+		}
+
+		p.total = n
+		return nil
+	}
+
+	if line == `*` {
+		p.squeeze = true
+		return nil
+	}
+
+	if strings.Contains(line, heksaSplitter) {
+		return p.parseHeksa(line)
+	}
+
+	if tokens := strings.Fields(strings.ReplaceAll(line, heksaPadding, ` `)); len(tokens) > 0 && isHexBytes(tokens) {
+		// heksa's hex column alone without offsets (-o no -f hex), offsets of other formats are longer
+		data, err := decodeTokens(tokens)
+		if err != nil {
+			return err
+		}
+
+		return p.line(nil, data)
+	}
+
+	if m := xxdMatcher.FindStringSubmatch(line); m != nil {
+		return p.parseXxd(m[1], m[2])
+	}
+
+	if m := offsetMatcher.FindStringSubmatch(line); m != nil {
+		return p.parseOffsetAndBytes(m[1], m[2])
+	}
+
+	return fmt.Errorf(`unknown format: %q`, line)
+}
+
+// parseHeksa parses heksa line such as "00000000┊48 65 6c 6c 6f ‡‡ ‡‡┊Hello‡‡"
+func (p *parser) parseHeksa(line string) error {
+	fields := strings.Split(line, heksaSplitter)
+
+	for i, field := range fields {
+		tokens := strings.Fields(strings.ReplaceAll(field, heksaPadding, ` `))
+		if len(tokens) == 0 || !isHexBytes(tokens) {
+			continue
+		}
+
+		data, err := decodeTokens(tokens)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			// Offset is the first column
+			offset, err := parseOffset(strings.TrimSpace(fields[0]))
+			if err != nil {
+				return err
+			}
+
+			return p.line(&offset, data)
+		}
+
+		return p.line(nil, data)
+	}
+
+	return fmt.Errorf(`no hex column found (only 'hex' formatter with 'hex' or no offsets is supported)`)
+}
+
+// parseXxd parses xxd line such as "00000000: 7f45 4c46 0201  .ELF.."
+func (p *parser) parseXxd(offsetStr string, rest string) error {
+	offset, err := parseOffset(offsetStr)
+	if err != nil {
+		return err
+	}
+
+	// Hex and ASCII columns are separated by two spaces
+	if idx := strings.Index(rest, `  `); idx != -1 {
+		rest = rest[:idx]
+	}
+
+	var tokens []string
+	for _, group := range strings.Fields(rest) {
+		if len(group)%2 != 0 {
+			return fmt.Errorf(`invalid hex group %q`, group)
+		}
+
+		for i := 0; i < len(group); i += 2 {
+			tokens = append(tokens, group[i:i+2])
+		}
+	}
+
+	data, err := decodeTokens(tokens)
+	if err != nil {
+		return err
+	}
+
+	return p.line(&offset, data)
+}
+
+// parseOffsetAndBytes parses hexdump -C and od -Ax -tx1 lines such as "00000000  7f 45 4c 46  |.ELF|"
+func (p *parser) parseOffsetAndBytes(offsetStr string, rest string) error {
+	offset, err := parseOffset(offsetStr)
+	if err != nil {
+		return err
+	}
+
+	// Remove hexdump's and od's ASCII gutters
+	if idx := strings.IndexAny(rest, `|>`); idx != -1 {
+		rest = rest[:idx]
+	}
+
+	data, err := decodeTokens(strings.Fields(rest))
+	if err != nil {
+		return err
+	}
+
+	return p.line(&offset, data)
+}
+
+// line handles one parsed line. offset is nil if the line didn't have offset.
+func (p *parser) line(offset *uint64, data []byte) error {
+	if offset != nil {
+		if (p.squeeze || p.repeat > 0) && *offset > p.pos {
+			// Fill squeezed lines up to the offset
+			err := p.fill(*offset)
+			if err != nil {
+				return err
+			}
+		}
+
+		p.pos = *offset
+	} else if p.repeat > 0 {
+		// Count includes the line that was printed before the marker
+		err := p.fill(p.pos + uint64(p.repeat-1)*uint64(len(p.last)))
+		if err != nil {
+			return err
+		}
+	}
+
+	p.squeeze = false
+	p.repeat = 0
+
+	if !p.hasFirst {
+		p.firstOffset = p.pos
+		p.hasFirst = true
+	}
+
+	if len(data) == 0 {
+		// Only offset, for example end offset of hexdump
+		return nil
+	}
+
+	err := p.out(p.pos, data)
+	if err != nil {
+		return err
+	}
+
+	p.last = data
+	p.pos += uint64(len(data))
+
+	return nil
+}
+
+// fill repeats the last line until end
+func (p *parser) fill(end uint64) error {
+	if len(p.last) == 0 {
+		return nil
+	}
+
+	for p.pos < end {
+		n := uint64(len(p.last))
+		if p.pos+n > end {
+			n = end - p.pos
+		}
+
+		err := p.out(p.pos, p.last[:n])
+		if err != nil {
+			return err
+		}
+
+		p.pos += n
+	}
+
+	return nil
+}
+
+// finish handles repeated lines at the end of dump
+func (p *parser) finish() error {
+	if p.repeat == 0 {
+		return nil
+	}
+
+	// At the end of heksa's dump the count doesn't include the printed line
+	end := p.pos + uint64(p.repeat)*uint64(len(p.last))
+
+	if p.total >= 0 && p.firstOffset+uint64(p.total) < end {
+		// Last line was a partial line
+		end = p.firstOffset + uint64(p.total)
+	}
+
+	return p.fill(end)
+}
+
+// isHexBytes tells if all tokens are two hex characters
+func isHexBytes(tokens []string) bool {
+	for _, t := range tokens {
+		if len(t) != 2 {
+			return false
+		}
+
+		if _, err := strconv.ParseUint(t, 16, 8); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeTokens(tokens []string) (data []byte, err error) {
+	for _, t := range tokens {
+		if len(t) != 2 {
+			return nil, fmt.Errorf(`invalid byte %q`, t)
+		}
+
+		n, err := strconv.ParseUint(t, 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid byte %q`, t)
+		}
+
+		data = append(data, byte(n))
+	}
+
+	return data, nil
+}
+
+func parseOffset(s string) (uint64, error) {
+	n, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf(`invalid offset %q`, s)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,112 @@
+package reverse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// parse parses dump into memory buffer
+func parse(t *testing.T, dump string) []byte {
+	var out []byte
+
+	err := Parse(strings.NewReader(dump), func(offset uint64, data []byte) error {
+		for uint64(len(out)) < offset+uint64(len(data)) {
+			out = append(out, 0)
+		}
+
+		copy(out[offset:], data)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+func TestHeksa(t *testing.T) {
+	dump := "\x1b[48;5;16m\x1b[38;5;250m00000000\x1b[38;5;194m┊\x1b[38;5;124m00 00 00 00┊ØØØØ\x1b[0m\n" +
+		"\t-- last line repeated 2 times (8 bytes (0x0008))\n" +
+		"\x1b[48;5;235m00000008┊68 69 ‡‡ ‡‡┊hi‡‡\x1b[0m\n" +
+		"\n" +
+		"Read 10 bytes total\n"
+
+	expected := []byte{0, 0, 0, 0, 0, 0, 0, 0, 'h', 'i'}
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+}
+
+func TestHeksaRepeatedAtEnd(t *testing.T) {
+	dump := "68 69┊hi\n" +
+		"\t-- last line repeated 2 times (4 bytes (0x0004))\n" +
+		"\n" +
+		"Read 5 bytes total\n"
+
+	expected := []byte(`hihih`)
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+}
+
+func TestXxd(t *testing.T) {
+	dump := "00000000: 7f45 4c46 0201  .ELF..\n" +
+		"00000006: 41                A\n"
+
+	expected := []byte{0x7f, 0x45, 0x4c, 0x46, 0x02, 0x01, 0x41}
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+}
+
+func TestHexdump(t *testing.T) {
+	dump := "00000000  61 61 61 61  |aaaa|\n" +
+		"*\n" +
+		"0000000c  62 62                                    |bb|\n" +
+		"0000000e\n"
+
+	expected := []byte(`aaaaaaaaaaaabb`)
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %q, got %q`, expected, got)
+	}
+}
+
+func TestOd(t *testing.T) {
+	dump := "000000 61 62\n" +
+		"000002 63\n" +
+		"000003\n"
+
+	expected := []byte(`abc`)
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %q, got %q`, expected, got)
+	}
+}
+
+func TestHeksaHexOnly(t *testing.T) {
+	// heksa -o no -f hex has no splitters and the first byte must not be read as an offset
+	dump := "\x1b[48;5;16m\x1b[38;5;69ma1 \x1b[38;5;68m65 6c 6c  6f 2c 20 77\x1b[0m\n" +
+		"\x1b[48;5;235m00 00 00 00  00 00 00 00\x1b[0m\n" +
+		"\t-- last line repeated 2 times (16 bytes (0x0010))\n" +
+		"\x1b[48;5;16m21 \x1b[38;5;237m‡‡ ‡‡ ‡‡  ‡‡ ‡‡ ‡‡ ‡‡\x1b[0m\n" +
+		"\n" +
+		"Read 25 bytes total\n"
+
+	expected := append(append([]byte{0xa1, 'e', 'l', 'l', 'o', ',', ' ', 'w'}, make([]byte, 16)...), '!')
+	got := parse(t, dump)
+
+	if !bytes.Equal(got, expected) {
+		t.Fatalf(`expected %v, got %v`, expected, got)
+	}
+}
@@ -0,0 +1,188 @@
+package stats
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/raspi/heksa/pkg/entropy"
+)
+
+const (
+	MinNGramSize = 2
+	MaxNGramSize = 4
+)
+
+// MaxNGramKeys is the count of distinct n-grams counted at a time.
+// When there are more, the least frequent half is dropped and counts become approximate.
+const MaxNGramKeys = 1 << 20
+
+// Run is a run of identical bytes
+type Run struct {
+	Byte   uint8  `json:"byte"`
+	Offset uint64 `json:"offset"`
+	Length uint64 `json:"length"`
+}
+
+// NGram is a sequence of bytes and how many times it occurred
+type NGram struct {
+	Bytes []byte `json:"-"`
+	Hex   string `json:"hex"`
+	Count uint64 `json:"count"`
+}
+
+// Result is the summary of all fed bytes
+type Result struct {
+	Offset     uint64            `json:"offset"` // Offset of the first byte
+	Total      uint64            `json:"total"`
+	Entropy    float64           `json:"entropy"` // Bits per byte
+	Histogram  [256]uint64       `json:"histogram"`
+	Groups     map[string]uint64 `json:"groups,omitempty"` // Byte color group counts, see CountGroups
+	LongestRun Run               `json:"longest_run"`
+	NGramSize  int               `json:"ngram_size"`
+	NGrams     []NGram           `json:"ngrams"`                // Most frequent first
+	NGramError uint64            `json:"ngram_error,omitempty"` // N-gram counts can be this much too high, 0 = exact
+}
+
+// Collector collects statistics from data fed in pieces
+type Collector struct {
+	offset     uint64
+	total      uint64
+	counts     [256]uint64
+	run        Run // Current run
+	longestRun Run
+	ngramSize  int
+	ngram      uint32 // Last ngramSize bytes
+	ngramMask  uint32
+	ngrams     map[uint32]uint64
+	ngramError uint64 // Highest count of dropped n-grams
+	maxNGrams  int    // See MaxNGramKeys
+}
+
+// New creates collector. offset is the offset of the first byte and ngramSize is 2 - 4.
+func New(offset uint64, ngramSize int) *Collector {
+	if ngramSize < MinNGramSize || ngramSize > MaxNGramSize {
+		panic(`invalid n-gram size`)
+	}
+
+	return &Collector{
+		offset:    offset,
+		ngramSize: ngramSize,
+		ngramMask: uint32((uint64(1) << (8 * uint(ngramSize))) - 1),
+		ngrams:    make(map[uint32]uint64),
+		maxNGrams: MaxNGramKeys,
+	}
+}
+
+// Feed adds bytes which continue from the previously fed bytes
+func (c *Collector) Feed(data []byte) {
+	for _, b := range data {
+		c.counts[b]++
+
+		if c.total > 0 && b == c.run.Byte {
+			c.run.Length++
+		} else {
+			c.run = Run{Byte: b, Offset: c.offset + c.total, Length: 1}
+		}
+
+		if c.run.Length > c.longestRun.Length {
+			c.longestRun = c.run
+		}
+
+		c.ngram = ((c.ngram << 8) | uint32(b)) & c.ngramMask
+		c.total++
+
+		if c.total >= uint64(c.ngramSize) {
+			c.countNGram(c.ngram)
+		}
+	}
+}
+
+// countNGram increments count of n-gram.
+// N-grams not seen before start from the highest dropped count, so that counts are never too low (space-saving algorithm).
+func (c *Collector) countNGram(k uint32) {
+	if _, ok := c.ngrams[k]; ok {
+		c.ngrams[k]++
+		return
+	}
+
+	if len(c.ngrams) >= c.maxNGrams {
+		c.pruneNGrams()
+	}
+
+	c.ngrams[k] = c.ngramError + 1
+}
+
+// pruneNGrams drops the least frequent half of n-grams
+func (c *Collector) pruneNGrams() {
+	counts := make([]uint64, 0, len(c.ngrams))
+	for _, count := range c.ngrams {
+		counts = append(counts, count)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i] < counts[j]
+	})
+
+	median := counts[len(counts)/2]
+
+	for k, count := range c.ngrams {
+		if count <= median {
+			delete(c.ngrams, k)
+		}
+	}
+
+	if median > c.ngramError {
+		c.ngramError = median
+	}
+}
+
+// Result returns statistics with top most frequent n-grams
+func (c *Collector) Result(top int) Result {
+	r := Result{
+		Offset:     c.offset,
+		Total:      c.total,
+		Entropy:    entropy.FromCounts(&c.counts, c.total),
+		Histogram:  c.counts,
+		LongestRun: c.longestRun,
+		NGramSize:  c.ngramSize,
+		NGrams:     []NGram{},
+		NGramError: c.ngramError,
+	}
+
+	keys := make([]uint32, 0, len(c.ngrams))
+	for k := range c.ngrams {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if c.ngrams[keys[i]] != c.ngrams[keys[j]] {
+			return c.ngrams[keys[i]] > c.ngrams[keys[j]]
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	if len(keys) > top {
+		keys = keys[0:top]
+	}
+
+	for _, k := range keys {
+		b := make([]byte, c.ngramSize)
+		for i := range b {
+			b[i] = byte(k >> (8 * uint(c.ngramSize-1-i)))
+		}
+
+		r.NGrams = append(r.NGrams, NGram{Bytes: b, Hex: hex.EncodeToString(b), Count: c.ngrams[k]})
+	}
+
+	return r
+}
+
+// CountGroups sets count of bytes in each byte group
+func (r *Result) CountGroups(byteGroups [256]string) {
+	r.Groups = make(map[string]uint64)
+
+	for b, count := range r.Histogram {
+		r.Groups[byteGroups[b]] += count
+	}
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	c := New(0x100, 2)
+
+	// Feed in pieces so that runs and n-grams continue over piece boundaries
+	c.Feed([]byte{1, 2, 1, 2, 0, 0})
+	c.Feed([]byte{0, 0, 1, 2})
+
+	r := c.Result(2)
+
+	if r.Total != 10 {
+		t.Fatalf(`expected total 10, got %d`, r.Total)
+	}
+
+	if r.Histogram[0] != 4 || r.Histogram[1] != 3 || r.Histogram[2] != 3 {
+		t.Fatalf(`invalid histogram: %v`, r.Histogram[0:3])
+	}
+
+	expectedRun := Run{Byte: 0, Offset: 0x104, Length: 4}
+	if r.LongestRun != expectedRun {
+		t.Fatalf(`expected run %+v, got %+v`, expectedRun, r.LongestRun)
+	}
+
+	// 01 02 occurs three times, 00 00 three times
+	if len(r.NGrams) != 2 {
+		t.Fatalf(`expected 2 n-grams, got %d`, len(r.NGrams))
+	}
+
+	if !bytes.Equal(r.NGrams[0].Bytes, []byte{0, 0}) || r.NGrams[0].Count != 3 {
+		t.Fatalf(`unexpected first n-gram %+v`, r.NGrams[0])
+	}
+
+	if !bytes.Equal(r.NGrams[1].Bytes, []byte{1, 2}) || r.NGrams[1].Count != 3 {
+		t.Fatalf(`unexpected second n-gram %+v`, r.NGrams[1])
+	}
+}
+
+func TestNGramLimit(t *testing.T) {
+	c := New(0, 2)
+	c.maxNGrams = 4
+
+	// 00 01 is frequent, rest of the 2-grams are unique
+	var data []byte
+	for i := byte(2); i < 100; i++ {
+		data = append(data, 0, 1, 0, 1, i, 0xff)
+	}
+
+	c.Feed(data)
+
+	if len(c.ngrams) > c.maxNGrams {
+		t.Fatalf(`expected at most %d n-grams, got %d`, c.maxNGrams, len(c.ngrams))
+	}
+
+	r := c.Result(1)
+
+	if r.NGramError == 0 {
+		t.Fatal(`expected approximate counts`)
+	}
+
+	if !bytes.Equal(r.NGrams[0].Bytes, []byte{0, 1}) {
+		t.Fatalf(`unexpected most frequent n-gram %+v`, r.NGrams[0])
+	}
+
+	// Counts are never too low
+	if exact := uint64(2 * 98); r.NGrams[0].Count < exact || r.NGrams[0].Count > exact+r.NGramError {
+		t.Fatalf(`count %d isn't within %d - %d`, r.NGrams[0].Count, exact, exact+r.NGramError)
+	}
+}
@@ -0,0 +1,129 @@
+package term
+
+import (
+	"bufio"
+)
+
+// KeyType is type of key press
+type KeyType uint8
+
+const (
+	KeyRune      KeyType = iota // Printable character, see Key.Rune
+	KeyCtrl                     // Ctrl + letter, see Key.Rune for the letter
+	KeyEnter                    // Enter
+	KeyBackspace                // Backspace
+	KeyTab                      // Tab
+	KeyEscape                   // Escape alone
+	KeyUp                       // Arrow up
+	KeyDown                     // Arrow down
+	KeyLeft                     // Arrow left
+	KeyRight                    // Arrow right
+	KeyHome                     // Home
+	KeyEnd                      // End
+	KeyPageUp                   // Page up
+	KeyPageDown                 // Page down
+	KeyInsert                   // Insert
+	KeyDelete                   // Delete
+	KeyUnknown                  // Unknown escape sequence
+)
+
+// Key is a decoded key press
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// Escape sequences ending with '~' (ESC [ n ~)
+var tildeKeys = map[string]KeyType{
+	`1`: KeyHome,
+	`2`: KeyInsert,
+	`3`: KeyDelete,
+	`4`: KeyEnd,
+	`5`: KeyPageUp,
+	`6`: KeyPageDown,
+	`7`: KeyHome,
+	`8`: KeyEnd,
+}
+
+// Escape sequences ending with a letter (ESC [ x or ESC O x)
+var letterKeys = map[byte]KeyType{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+}
+
+// ReadKey reads one key press from terminal in raw mode.
+// Escape key alone is told apart from escape sequences by checking if the rest of the sequence was received at the same time.
+func ReadKey(r *bufio.Reader) (k Key, err error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return k, err
+	}
+
+	switch {
+	case c == '\r' || c == '\n':
+		return Key{Type: KeyEnter}, nil
+	case c == 0x7f || c == 0x08:
+		return Key{Type: KeyBackspace}, nil
+	case c == '\t':
+		return Key{Type: KeyTab}, nil
+	case c == 0x1b:
+		if r.Buffered() == 0 {
+			return Key{Type: KeyEscape}, nil
+		}
+
+		return readEscapeSequence(r)
+	case c < 0x20:
+		return Key{Type: KeyCtrl, Rune: 'a' + c - 1}, nil
+	}
+
+	return Key{Type: KeyRune, Rune: c}, nil
+}
+
+// readEscapeSequence decodes rest of the escape sequence after ESC
+func readEscapeSequence(r *bufio.Reader) (k Key, err error) {
+	k.Type = KeyUnknown
+
+	c, err := r.ReadByte()
+	if err != nil {
+		return k, err
+	}
+
+	if c != '[' && c != 'O' {
+		// Alt + key
+		return k, nil
+	}
+
+	var params []byte
+
+	for {
+		c, err = r.ReadByte()
+		if err != nil {
+			return k, err
+		}
+
+		if c >= 0x40 && c <= 0x7e {
+			// Final byte of the sequence
+			break
+		}
+
+		params = append(params, c)
+	}
+
+	if c == '~' {
+		if t, ok := tildeKeys[string(params)]; ok {
+			k.Type = t
+		}
+
+		return k, nil
+	}
+
+	if t, ok := letterKeys[c]; ok {
+		k.Type = t
+	}
+
+	return k, nil
+}
@@ -0,0 +1,48 @@
+package term
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("a\x1b[A\x1b[6~\x1bOH\x1b[1;5C\r\x7f\x07ä\x1b[99~"))
+
+	expected := []Key{
+		{Type: KeyRune, Rune: 'a'},
+		{Type: KeyUp},
+		{Type: KeyPageDown},
+		{Type: KeyHome},
+		{Type: KeyRight},
+		{Type: KeyEnter},
+		{Type: KeyBackspace},
+		{Type: KeyCtrl, Rune: 'g'},
+		{Type: KeyRune, Rune: 'ä'},
+		{Type: KeyUnknown},
+	}
+
+	for i, e := range expected {
+		k, err := ReadKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if k != e {
+			t.Errorf(`key #%d: expected %v, got %v`, i, e, k)
+		}
+	}
+}
+
+func TestReadKeyEscapeAlone(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("\x1b"))
+
+	k, err := ReadKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if k.Type != KeyEscape {
+		t.Errorf(`expected escape, got %v`, k)
+	}
+}
@@ -0,0 +1,94 @@
+//go:build linux
+// +build linux
+
+package term
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// State is the terminal state before raw mode was enabled
+type State struct {
+	termios syscall.Termios
+}
+
+// winsize is struct winsize from <sys/ioctl.h>
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// IsTerminal tells if file descriptor is a terminal
+func IsTerminal(fd int) bool {
+	var t syscall.Termios
+	return ioctl(fd, syscall.TCGETS, unsafe.Pointer(&t)) == nil
+}
+
+// MakeRaw puts terminal into raw mode: input is not echoed, keys are read one by one and signals are not generated.
+// Returned state is used for restoring the terminal with Restore.
+func MakeRaw(fd int) (*State, error) {
+	var old syscall.Termios
+
+	err := ioctl(fd, syscall.TCGETS, unsafe.Pointer(&old))
+	if err != nil {
+		return nil, fmt.Errorf(`couldn't get terminal attributes: %w`, err)
+	}
+
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	err = ioctl(fd, syscall.TCSETS, unsafe.Pointer(&raw))
+	if err != nil {
+		return nil, fmt.Errorf(`couldn't set terminal attributes: %w`, err)
+	}
+
+	return &State{termios: old}, nil
+}
+
+// Restore restores terminal state saved by MakeRaw
+func Restore(fd int, state *State) error {
+	err := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&state.termios))
+	if err != nil {
+		return fmt.Errorf(`couldn't restore terminal attributes: %w`, err)
+	}
+
+	return nil
+}
+
+// GetSize returns terminal size in characters
+func GetSize(fd int) (width int, height int, err error) {
+	var ws winsize
+
+	err = ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws))
+	if err != nil {
+		return 0, 0, fmt.Errorf(`couldn't get terminal size: %w`, err)
+	}
+
+	return int(ws.Col), int(ws.Row), nil
+}
+
+// NotifyResize sends a signal to c when terminal is resized
+func NotifyResize(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGWINCH)
+}
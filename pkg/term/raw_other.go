@@ -0,0 +1,35 @@
+//go:build !linux
+// +build !linux
+
+package term
+
+import (
+	"os"
+)
+
+// State is the terminal state before raw mode was enabled
+type State struct{}
+
+// IsTerminal tells if file descriptor is a terminal
+func IsTerminal(fd int) bool {
+	return false
+}
+
+// MakeRaw is not supported on this operating system
+func MakeRaw(fd int) (*State, error) {
+	return nil, ErrUnsupported
+}
+
+// Restore is not supported on this operating system
+func Restore(fd int, state *State) error {
+	return ErrUnsupported
+}
+
+// GetSize is not supported on this operating system
+func GetSize(fd int) (width int, height int, err error) {
+	return 0, 0, ErrUnsupported
+}
+
+// NotifyResize does nothing on this operating system
+func NotifyResize(c chan<- os.Signal) {
+}
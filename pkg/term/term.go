@@ -0,0 +1,27 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ANSI escape codes for controlling the terminal screen
+const (
+	EnterAltScreen = "\x1b[?1049h" // Use alternate screen so that the previous terminal content is restored on exit
+	LeaveAltScreen = "\x1b[?1049l"
+	HideCursor     = "\x1b[?25l"
+	ShowCursor     = "\x1b[?25h"
+	DisableWrap    = "\x1b[?7l" // Long lines are cut at the right edge instead of wrapping
+	EnableWrap     = "\x1b[?7h"
+	Home           = "\x1b[H"  // Move cursor to top left corner
+	ClearLine      = "\x1b[K"  // Clear from cursor to the end of the line
+	ClearScreen    = "\x1b[2J" // Clear whole screen
+)
+
+// ErrUnsupported is returned when raw mode isn't implemented for the operating system
+var ErrUnsupported = errors.New(`terminal raw mode is not supported on this operating system`)
+
+// MoveTo returns escape code which moves cursor to given row and column (1-based)
+func MoveTo(row int, column int) string {
+	return fmt.Sprintf("\x1b[%d;%dH", row, column)
+}
@@ -0,0 +1,252 @@
+package visual
+
+import (
+	"fmt"
+	"image"
+	imgcolor "image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/entropy"
+)
+
+type Layout uint8
+
+const (
+	LayoutLinear  Layout = iota // Bytes in rows from left to right
+	LayoutHilbert               // Bytes along a Hilbert curve, nearby bytes stay near each other
+	LayoutDigraph               // 256x256 frequency plot of byte pairs
+)
+
+// Get enum from string
+var layoutStringToEnumMap = map[string]Layout{
+	`linear`:  LayoutLinear,
+	`hilbert`: LayoutHilbert,
+	`digraph`: LayoutDigraph,
+}
+
+// GetLayout returns layout from string
+func GetLayout(s string) (Layout, error) {
+	l, ok := layoutStringToEnumMap[s]
+	if !ok {
+		return l, fmt.Errorf(`invalid: %q, valid: %v`, s, strings.Join(GetLayoutList(), `, `))
+	}
+
+	return l, nil
+}
+
+// GetLayoutList lists layouts as strings for usage information
+func GetLayoutList() (layouts []string) {
+	for s := range layoutStringToEnumMap {
+		layouts = append(layouts, s)
+	}
+
+	sort.Strings(layouts)
+	return layouts
+}
+
+// Canvas draws consecutive chunks of the input to an image, so that the whole input doesn't have to be in memory.
+// Chunks must be multiples of EntropyBlockSize bytes, only the last chunk may be shorter.
+type Canvas interface {
+	Draw(data []byte)
+	Image() *image.NRGBA
+}
+
+// Check implementation
+var _ Canvas = &LinearCanvas{}
+var _ Canvas = &HilbertCanvas{}
+var _ Canvas = &DigraphCanvas{}
+
+// LinearCanvas renders one pixel per byte, width pixels per row.
+// Pixels after the last byte are transparent.
+type LinearCanvas struct {
+	colorizer Colorizer
+	img       *image.NRGBA
+	width     int
+	pos       int
+}
+
+// NewLinear creates linear canvas for size bytes
+func NewLinear(colorizer Colorizer, size int, width int) *LinearCanvas {
+	height := (size + width - 1) / width
+	if height == 0 {
+		height = 1
+	}
+
+	return &LinearCanvas{
+		colorizer: colorizer,
+		img:       image.NewNRGBA(image.Rect(0, 0, width, height)),
+		width:     width,
+	}
+}
+
+func (c *LinearCanvas) Draw(data []byte) {
+	for _, col := range c.colorizer.Colors(data) {
+		c.img.SetNRGBA(c.pos%c.width, c.pos/c.width, col)
+		c.pos++
+	}
+}
+
+func (c *LinearCanvas) Image() *image.NRGBA {
+	return c.img
+}
+
+// HilbertCanvas renders bytes along a Hilbert curve in a square image.
+// Side of the square is maxSide rounded up to a power of two, or smaller if the data fits in a smaller square.
+// If there are more bytes than pixels, a pixel is the average color of consecutive bytes.
+type HilbertCanvas struct {
+	colorizer Colorizer
+	img       *image.NRGBA
+	side      int
+	perPixel  int
+	pixel     int // Current pixel along the curve
+	count     int // Bytes added to the current pixel
+	r, g, b   int // Sum of colors of the current pixel
+}
+
+// NewHilbert creates Hilbert curve canvas for size bytes
+func NewHilbert(colorizer Colorizer, size int, maxSide int) *HilbertCanvas {
+	side := 1
+	for side < maxSide && side*side < size {
+		side *= 2
+	}
+
+	pixels := side * side
+	perPixel := (size + pixels - 1) / pixels
+	if perPixel == 0 {
+		perPixel = 1
+	}
+
+	return &HilbertCanvas{
+		colorizer: colorizer,
+		img:       image.NewNRGBA(image.Rect(0, 0, side, side)),
+		side:      side,
+		perPixel:  perPixel,
+	}
+}
+
+func (c *HilbertCanvas) Draw(data []byte) {
+	for _, col := range c.colorizer.Colors(data) {
+		c.r += int(col.R)
+		c.g += int(col.G)
+		c.b += int(col.B)
+		c.count++
+
+		if c.count == c.perPixel {
+			c.flush()
+		}
+	}
+}
+
+// flush sets the current pixel to the average color of its bytes and moves to the next pixel
+func (c *HilbertCanvas) flush() {
+	if c.count == 0 || c.pixel >= c.side*c.side {
+		return
+	}
+
+	x, y := hilbertPoint(c.side, c.pixel)
+	c.img.SetNRGBA(x, y, imgcolor.NRGBA{R: uint8(c.r / c.count), G: uint8(c.g / c.count), B: uint8(c.b / c.count), A: 0xff})
+
+	c.pixel++
+	c.count, c.r, c.g, c.b = 0, 0, 0, 0
+}
+
+// Image returns the image, bytes of a partially filled last pixel are averaged too
+func (c *HilbertCanvas) Image() *image.NRGBA {
+	c.flush()
+	return c.img
+}
+
+// hilbertPoint converts distance d along the Hilbert curve to coordinates in a side x side square
+func hilbertPoint(side int, d int) (x int, y int) {
+	for s := 1; s < side; s *= 2 {
+		rx := 1 & (d / 2)
+		ry := 1 & (d ^ rx)
+
+		// Rotate quadrant
+		if ry == 0 {
+			if rx == 1 {
+				x = s - 1 - x
+				y = s - 1 - y
+			}
+
+			x, y = y, x
+		}
+
+		x += s * rx
+		y += s * ry
+		d /= 4
+	}
+
+	return x, y
+}
+
+// DigraphCanvas renders 256x256 image where pixel at (x, y) tells how often byte x is followed by byte y.
+// Counts are on logarithmic scale and colored like entropy.
+type DigraphCanvas struct {
+	counts  []uint64
+	max     uint64
+	prev    byte
+	hasPrev bool
+}
+
+func NewDigraph() *DigraphCanvas {
+	return &DigraphCanvas{
+		counts: make([]uint64, 256*256),
+	}
+}
+
+func (c *DigraphCanvas) Draw(data []byte) {
+	for _, b := range data {
+		if c.hasPrev {
+			idx := int(c.prev)*256 + int(b)
+			c.counts[idx]++
+
+			if c.counts[idx] > c.max {
+				c.max = c.counts[idx]
+			}
+		}
+
+		c.prev = b
+		c.hasPrev = true
+	}
+}
+
+func (c *DigraphCanvas) Image() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+
+	for idx, n := range c.counts {
+		level := 0.0
+		if n > 0 {
+			// Pairs which exist are never completely black
+			level = (0.1 + 0.9*math.Log(float64(n))/math.Log(float64(c.max)+1)) * entropy.MaxBits
+		}
+
+		img.SetNRGBA(idx/256, idx%256, EntropyColor(level))
+	}
+
+	return img
+}
+
+// average returns average of colors, transparent colors are ignored
+func average(colors []imgcolor.NRGBA) imgcolor.NRGBA {
+	var r, g, b, n int
+
+	for _, c := range colors {
+		if c.A == 0 {
+			continue
+		}
+
+		r += int(c.R)
+		g += int(c.G)
+		b += int(c.B)
+		n++
+	}
+
+	if n == 0 {
+		return imgcolor.NRGBA{}
+	}
+
+	return imgcolor.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 0xff}
+}
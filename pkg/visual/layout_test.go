@@ -0,0 +1,85 @@
+package visual
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/raspi/heksa/pkg/color"
+)
+
+func TestHilbertPoint(t *testing.T) {
+	for _, side := range []int{1, 2, 4, 16, 64} {
+		seen := make(map[[2]int]bool)
+		lastX, lastY := 0, 0
+
+		for d := 0; d < side*side; d++ {
+			x, y := hilbertPoint(side, d)
+
+			if x < 0 || y < 0 || x >= side || y >= side {
+				t.Fatalf(`side %d: point %d (%d, %d) is outside`, side, d, x, y)
+			}
+
+			if seen[[2]int{x, y}] {
+				t.Fatalf(`side %d: point %d (%d, %d) visited twice`, side, d, x, y)
+			}
+
+			seen[[2]int{x, y}] = true
+
+			// Each step moves to a neighbor pixel
+			if d > 0 {
+				dx, dy := x-lastX, y-lastY
+				if dx*dx+dy*dy != 1 {
+					t.Fatalf(`side %d: point %d (%d, %d) isn't next to (%d, %d)`, side, d, x, y, lastX, lastY)
+				}
+			}
+
+			lastX, lastY = x, y
+		}
+	}
+}
+
+func TestCanvasChunks(t *testing.T) {
+	data := make([]byte, 3*EntropyBlockSize+17)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	colorizer := NewColorizer(ModeEntropy, [256]color.RGB{})
+
+	canvases := func() []Canvas {
+		return []Canvas{
+			NewLinear(colorizer, len(data), 32),
+			NewHilbert(colorizer, len(data), 16),
+			NewDigraph(),
+		}
+	}
+
+	whole := canvases()
+	chunked := canvases()
+
+	for i := range whole {
+		whole[i].Draw(data)
+
+		// Drawing in chunks of EntropyBlockSize gives the same image
+		for start := 0; start < len(data); start += EntropyBlockSize {
+			end := start + EntropyBlockSize
+			if end > len(data) {
+				end = len(data)
+			}
+
+			chunked[i].Draw(data[start:end])
+		}
+
+		if !bytes.Equal(whole[i].Image().Pix, chunked[i].Image().Pix) {
+			t.Errorf(`canvas %T: chunked image differs`, whole[i])
+		}
+	}
+
+	if b := whole[0].Image().Bounds(); b.Dx() != 32 || b.Dy() != (len(data)+31)/32 {
+		t.Errorf(`linear: unexpected size %v`, b)
+	}
+
+	if b := whole[1].Image().Bounds(); b.Dx() != 16 || b.Dy() != 16 {
+		t.Errorf(`hilbert: unexpected size %v`, b)
+	}
+}
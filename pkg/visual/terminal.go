@@ -0,0 +1,80 @@
+package visual
+
+import (
+	"image"
+	imgcolor "image/color"
+	"strconv"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+)
+
+// Downscale shrinks image by an integer factor so that it's at most maxWidth pixels wide.
+// Pixels are averages of the original pixels.
+func Downscale(img *image.NRGBA, maxWidth int) *image.NRGBA {
+	bounds := img.Bounds()
+
+	factor := (bounds.Dx() + maxWidth - 1) / maxWidth
+	if factor <= 1 {
+		return img
+	}
+
+	w := (bounds.Dx() + factor - 1) / factor
+	h := (bounds.Dy() + factor - 1) / factor
+	scaled := image.NewNRGBA(image.Rect(0, 0, w, h))
+	block := make([]imgcolor.NRGBA, 0, factor*factor)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			block = block[:0]
+
+			for by := y * factor; by < (y+1)*factor && by < bounds.Dy(); by++ {
+				for bx := x * factor; bx < (x+1)*factor && bx < bounds.Dx(); bx++ {
+					block = append(block, img.NRGBAAt(bounds.Min.X+bx, bounds.Min.Y+by))
+				}
+			}
+
+			scaled.SetNRGBA(x, y, average(block))
+		}
+	}
+
+	return scaled
+}
+
+// Terminal draws image with ANSI 256 colors using half-block characters, so one character is two pixels on top of each other
+func Terminal(img *image.NRGBA) (lines []string) {
+	bounds := img.Bounds()
+	sb := strings.Builder{}
+
+	ansi := func(c imgcolor.NRGBA) string {
+		return strconv.Itoa(int(color.RGBToANSI256(color.RGB{R: c.R, G: c.G, B: c.B})))
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		sb.Reset()
+
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := img.NRGBAAt(x, y)
+			bottom := imgcolor.NRGBA{}
+			if y+1 < bounds.Max.Y {
+				bottom = img.NRGBAAt(x, y+1)
+			}
+
+			switch {
+			case top.A == 0 && bottom.A == 0:
+				sb.WriteString(color.Clear + ` `)
+			case top.A == 0:
+				sb.WriteString(color.Clear + color.SetForeground + ansi(bottom) + "m▄")
+			case bottom.A == 0:
+				sb.WriteString(color.Clear + color.SetForeground + ansi(top) + "m▀")
+			default:
+				sb.WriteString(color.SetForeground + ansi(top) + "m" + color.SetBackground + ansi(bottom) + "m▀")
+			}
+		}
+
+		sb.WriteString(color.Clear)
+		lines = append(lines, sb.String())
+	}
+
+	return lines
+}
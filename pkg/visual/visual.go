@@ -0,0 +1,135 @@
+package visual
+
+import (
+	"fmt"
+	imgcolor "image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/entropy"
+)
+
+type Mode uint8
+
+const (
+	ModePalette Mode = iota // Byte color palette
+	ModeGray                // Byte value as gray level
+	ModeEntropy             // Entropy of the surrounding block
+)
+
+// Get enum from string
+var modeStringToEnumMap = map[string]Mode{
+	`palette`: ModePalette,
+	`gray`:    ModeGray,
+	`entropy`: ModeEntropy,
+}
+
+// GetMode returns mode from string
+func GetMode(s string) (Mode, error) {
+	m, ok := modeStringToEnumMap[s]
+	if !ok {
+		return m, fmt.Errorf(`invalid: %q, valid: %v`, s, strings.Join(GetModeList(), `, `))
+	}
+
+	return m, nil
+}
+
+// GetModeList lists modes as strings for usage information
+func GetModeList() (modes []string) {
+	for s := range modeStringToEnumMap {
+		modes = append(modes, s)
+	}
+
+	sort.Strings(modes)
+	return modes
+}
+
+// EntropyBlockSize is the count of bytes used for calculating entropy of a single pixel in ModeEntropy
+const EntropyBlockSize = 256
+
+// Colorizer gives a color for each byte of the data
+type Colorizer struct {
+	mode    Mode
+	palette [256]imgcolor.NRGBA
+}
+
+// NewColorizer creates colorizer. bytePalette is used for ModePalette.
+func NewColorizer(mode Mode, bytePalette [256]color.RGB) Colorizer {
+	c := Colorizer{
+		mode: mode,
+	}
+
+	for i := range c.palette {
+		switch mode {
+		case ModePalette:
+			c.palette[i] = imgcolor.NRGBA{R: bytePalette[i].R, G: bytePalette[i].G, B: bytePalette[i].B, A: 0xff}
+		case ModeGray:
+			c.palette[i] = imgcolor.NRGBA{R: uint8(i), G: uint8(i), B: uint8(i), A: 0xff}
+		case ModeEntropy:
+			c.palette[i] = EntropyColor(float64(i) / 255 * entropy.MaxBits)
+		}
+	}
+
+	return c
+}
+
+// Colors returns color of each byte of data
+func (c Colorizer) Colors(data []byte) []imgcolor.NRGBA {
+	colors := make([]imgcolor.NRGBA, len(data))
+
+	if c.mode != ModeEntropy {
+		for i, b := range data {
+			colors[i] = c.palette[b]
+		}
+
+		return colors
+	}
+
+	for start := 0; start < len(data); start += EntropyBlockSize {
+		end := start + EntropyBlockSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		level := uint8(math.Round(entropy.Shannon(data[start:end]) / entropy.MaxBits * 255))
+
+		for i := start; i < end; i++ {
+			colors[i] = c.palette[level]
+		}
+	}
+
+	return colors
+}
+
+// EntropyColor returns color for entropy (0 - 8 bits): black -> blue -> magenta -> red -> yellow
+func EntropyColor(e float64) imgcolor.NRGBA {
+	stops := []imgcolor.NRGBA{
+		{0x00, 0x00, 0x00, 0xff},
+		{0x00, 0x00, 0xc0, 0xff},
+		{0xc0, 0x00, 0xc0, 0xff},
+		{0xff, 0x00, 0x00, 0xff},
+		{0xff, 0xff, 0x40, 0xff},
+	}
+
+	pos := e / entropy.MaxBits * float64(len(stops)-1)
+	if pos <= 0 {
+		return stops[0]
+	}
+
+	if pos >= float64(len(stops)-1) {
+		return stops[len(stops)-1]
+	}
+
+	idx := int(pos)
+	frac := pos - float64(idx)
+
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*frac))
+	}
+
+	a, b := stops[idx], stops[idx+1]
+
+	return imgcolor.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 0xff}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/overview"
+	"github.com/raspi/heksa/pkg/reader/offsetFormatters/human"
+	"github.com/raspi/heksa/pkg/regions"
+)
+
+// Color group of each region kind
+var regionKindColorGroups = map[regions.Kind]string{
+	regions.KindPadding:     `NullEOF`,
+	regions.KindText:        `Printable`,
+	regions.KindLowEntropy:  `Special`,
+	regions.KindMixed:       `Default`,
+	regions.KindHighEntropy: `UpperByte`,
+}
+
+// runRegions prints a map of ranges classified by entropy and byte classes
+func runRegions(p params) {
+	in := p.inputs[0]
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+	sizeFormatter := human.New(1024)
+	analyzer := overview.New(getGroupBytes(p.byteGroups, []string{`NullEOF`}), getPrintableBytes(p.byteGroups))
+	slider := regions.NewSlider(analyzer, getInputOffset(in), p.regionsWindow)
+
+	var src io.Reader = in.source
+	if p.limit > 0 {
+		src = io.LimitReader(src, int64(p.limit))
+	}
+
+	buf := make([]byte, p.regionsWindow)
+	var total uint64
+
+	for {
+		n, err := io.ReadFull(src, buf)
+		slider.Feed(buf[0:n])
+		total += uint64(n)
+
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+	}
+
+	closeInputs(p.inputs)
+	slider.Flush()
+
+	splitter := p.colorGroupings[`Splitter`] + p.fg.Splitter
+	sb := strings.Builder{}
+
+	for _, r := range slider.Regions() {
+		sb.Reset()
+		sb.WriteString(p.colorGroupings[`Offset`])
+		sb.WriteString(offsetFormatter.Print(r.Start))
+		sb.WriteString(`-`)
+		sb.WriteString(offsetFormatter.Print(r.End()))
+		sb.WriteString(splitter)
+		sb.WriteString(p.colorGroupings[`Offset`])
+		sb.WriteString(sizeFormatter.Print(r.Size))
+		sb.WriteString(splitter)
+		sb.WriteString(p.colorGroupings[regionKindColorGroups[r.Kind]])
+		sb.WriteString(fmt.Sprintf(`%-20s`, r.Kind))
+		sb.WriteString(splitter)
+		sb.WriteString(p.colorGroupings[`Offset`])
+		sb.WriteString(fmt.Sprintf(`entropy %.2f`, r.Entropy))
+		sb.WriteString(color.Clear)
+		_, _ = fmt.Println(sb.String())
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, total))
+}
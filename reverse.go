@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/reverse"
+)
+
+// runReverse converts text dump back to binary
+func runReverse(p params) {
+	in := p.inputs[0]
+	var written uint64
+
+	var out reverse.Output
+
+	if p.output != `` {
+		// Patch file in place
+		f, err := os.OpenFile(p.output, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error opening output file: %v\n", err)
+			os.Exit(1)
+		}
+
+		defer func() {
+			err := f.Close()
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "couldn't close output file: %v\n", err)
+				os.Exit(1)
+			}
+		}()
+
+		out = func(offset uint64, data []byte) error {
+			pos := int64(offset) + p.seek
+			if pos < 0 {
+				return fmt.Errorf(`offset %d is negative`, pos)
+			}
+
+			_, err := f.WriteAt(data, pos)
+			written += uint64(len(data))
+			return err
+		}
+	} else {
+		// STDOUT can't seek, so gaps are filled with zeroes
+		w := bufio.NewWriter(os.Stdout)
+		defer w.Flush()
+
+		var pos uint64
+		isFirst := true
+
+		out = func(offset uint64, data []byte) error {
+			if isFirst {
+				pos = offset
+				isFirst = false
+			}
+
+			if offset < pos {
+				return fmt.Errorf(`offset 0x%x goes backwards, use --output`, offset)
+			}
+
+			if offset > pos {
+				_, err := io.Copy(w, bytes.NewReader(make([]byte, offset-pos)))
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err := w.Write(data)
+			pos = offset + uint64(len(data))
+			written += uint64(len(data))
+			return err
+		}
+	}
+
+	err := reverse.Parse(in.source, out)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error parsing dump %v: %v\n", in.name, err)
+		os.Exit(1)
+	}
+
+	err = in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "couldn't close file: %v\n", err)
+		os.Exit(1)
+	}
+
+	if p.output != `` {
+		_, _ = fmt.Println(fmt.Sprintf(`Wrote %d bytes to %v`, written, p.output))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/find"
+	"github.com/raspi/heksa/pkg/reader"
+	offFormatters "github.com/raspi/heksa/pkg/reader/offsetFormatters/base"
+)
+
+// getOffsetFormatter returns the first selected offset formatter for listings, hex is used if offsets are disabled
+func getOffsetFormatter(p params, filesize int64) offFormatters.OffsetFormatter {
+	f := reader.OffsetHex
+	if len(p.offsetViewer) > 0 {
+		f = p.offsetViewer[0]
+	}
+
+	return reader.GetFromOffsetFormatter(f, offFormatters.BaseInfo{FileSize: filesize})
+}
+
+// runSearch lists offsets where typed value was found
+func runSearch(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+	scanner := find.NewScanner(p.findPattern)
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+
+	var matchCount uint64
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		for _, offset := range scanner.Feed(l.Offset, l.Data) {
+			matchCount++
+			_, _ = fmt.Println(p.colorGroupings[`Offset`] + offsetFormatter.Print(offset) + color.Clear)
+		}
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	err := in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	_, _ = fmt.Println()
+	_, _ = fmt.Println(fmt.Sprintf(`Read %d bytes total`, r.GetReadBytes()))
+	_, _ = fmt.Println(fmt.Sprintf(`%d matches`, matchCount))
+}
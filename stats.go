@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/output"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/ascii"
+	"github.com/raspi/heksa/pkg/reader/byteFormatters/hex"
+	"github.com/raspi/heksa/pkg/stats"
+)
+
+// Width of the longest bar in characters
+const statsBarWidth = 40
+
+// runStats prints byte statistics of the input
+func runStats(p params) {
+	in := p.inputs[0]
+	offsetFormatter := getOffsetFormatter(p, in.filesize)
+
+	offset := getInputOffset(in)
+
+	var src io.Reader = in.source
+	if p.limit > 0 {
+		src = io.LimitReader(src, int64(p.limit))
+	}
+
+	collector := stats.New(offset, p.statsNGram)
+	buf := make([]byte, 64*1024)
+
+	for {
+		n, err := src.Read(buf)
+		collector.Feed(buf[0:n])
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+	}
+
+	closeInputs(p.inputs)
+
+	result := collector.Result(p.statsTop)
+	result.CountGroups(p.byteGroups)
+
+	switch p.outputFormat {
+	case output.FormatJSON, output.FormatNDJSON:
+		var b []byte
+		var err error
+
+		if p.outputFormat == output.FormatJSON {
+			b, err = json.MarshalIndent(result, ``, `  `)
+		} else {
+			b, err = json.Marshal(result)
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, `error encoding JSON: %v`, err)
+			os.Exit(1)
+		}
+
+		_, _ = fmt.Println(string(b))
+		return
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	end := result.Offset
+	if result.Total > 0 {
+		end += result.Total - 1
+	}
+
+	_, _ = fmt.Fprintf(w, "Range:        %s - %s\n", offsetFormatter.Print(result.Offset), offsetFormatter.Print(end))
+	_, _ = fmt.Fprintf(w, "Bytes:        %d\n", result.Total)
+	_, _ = fmt.Fprintf(w, "Entropy:      %.4f bits per byte\n", result.Entropy)
+
+	if result.Total > 0 {
+		run := result.LongestRun
+		_, _ = fmt.Fprintf(w, "Longest run:  %s%s%s x %d at %s\n",
+			p.palette[run.Byte], hex.HexByteToString[run.Byte], color.Clear, run.Length, offsetFormatter.Print(run.Offset))
+	}
+
+	// Byte groups, largest first
+	var groups []string
+	for name := range result.Groups {
+		groups = append(groups, name)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if result.Groups[groups[i]] != result.Groups[groups[j]] {
+			return result.Groups[groups[i]] > result.Groups[groups[j]]
+		}
+
+		return groups[i] < groups[j]
+	})
+
+	var max uint64
+	for _, count := range result.Groups {
+		if count > max {
+			max = count
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `Byte groups:`)
+
+	for _, name := range groups {
+		count := result.Groups[name]
+		if count == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "  %-12s %12d %7.3f%% %s\n", name, count, percentage(count, result.Total), bar(p.colorGroupings[name], count, max))
+	}
+
+	// Histogram of byte values which occur
+	max = 0
+	for _, count := range result.Histogram {
+		if count > max {
+			max = count
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `Histogram:`)
+
+	for b, count := range result.Histogram {
+		if count == 0 {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s%s %c%s %12d %7.3f%% %s\n",
+			p.palette[b], hex.HexByteToString[b], ascii.AsciiByteToChar[b], color.Clear,
+			count, percentage(count, result.Total), bar(p.palette[b], count, max))
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "Most frequent %d-grams:\n", result.NGramSize)
+
+	if result.NGramError > 0 {
+		_, _ = fmt.Fprintf(w, "  (too many distinct %d-grams, counts are approximate and can be up to %d too high)\n", result.NGramSize, result.NGramError)
+	}
+
+	for _, ng := range result.NGrams {
+		var hexSb, ascSb strings.Builder
+
+		for i, b := range ng.Bytes {
+			if i > 0 {
+				hexSb.WriteString(` `)
+			}
+
+			hexSb.WriteString(p.palette[b] + hex.HexByteToString[b])
+			ascSb.WriteString(p.palette[b] + string(ascii.AsciiByteToChar[b]))
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s%s %s%s %12d\n", hexSb.String(), color.Clear, ascSb.String(), color.Clear, ng.Count)
+	}
+}
+
+// bar returns colored bar whose length is relative to max
+func bar(clr string, count uint64, max uint64) string {
+	if max == 0 || count == 0 {
+		return ``
+	}
+
+	n := int(count * statsBarWidth / max)
+	if n == 0 {
+		// Show that there is something
+		n = 1
+	}
+
+	return clr + strings.Repeat(`█`, n) + color.Clear
+}
+
+// percentage returns count of total in percents
+func percentage(count uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(count) * 100 / float64(total)
+}
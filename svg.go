@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/raspi/heksa/pkg/color"
+	"github.com/raspi/heksa/pkg/output/svgOutput"
+)
+
+// runSVG dumps input as SVG image
+func runSVG(p params) {
+	in := p.inputs[0]
+	r := newReader(p, in)
+	offsetFormatters := getOffsetFormatters(p, in)
+
+	groupColors, err := color.GetColorGroupIndexes(strings.NewReader(DefaultGroupColors))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error loading color group config: %v`, err)
+		os.Exit(1)
+	}
+
+	renderer := svgOutput.New(groupColors, p.byteGroups, p.fg.Splitter)
+
+	// Relative offset is not available for STDIN
+	printRelative := p.printRelative && in.filesize != -1
+
+	for {
+		l, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// End of File
+				break
+			}
+
+			_, _ = fmt.Fprintln(os.Stderr, fmt.Sprintf(`error while reading file: %v`, err))
+			os.Exit(1)
+		}
+
+		offsetsLeft, offsetsRight := getLineOffsets(offsetFormatters, l, printRelative)
+		renderer.Line(offsetsLeft, offsetsRight, l.Data, p.fg.Layout(l.Data))
+
+		if p.limit > 0 && r.GetReadBytes() >= p.limit {
+			// Limit is set and found
+			break
+		}
+	}
+
+	err = in.source.Close()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `couldn't close file: %v`, err)
+		os.Exit(1)
+	}
+
+	w, closeOutput := openOutput(p)
+
+	_, err = renderer.WriteTo(w)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, `error writing output: %v`, err)
+		os.Exit(1)
+	}
+
+	closeOutput()
+}